@@ -8,6 +8,7 @@
 #include <stddef.h>
 #include <functional>
 #include <thread>
+#include <type_traits>
 
 #include "builtin.hpp"
 #include "error.hpp"
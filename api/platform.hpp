@@ -33,4 +33,11 @@
 #define ARCH_32BIT
 #endif
 
+// Clang supports hot attribute since version 12.
+#if defined(__clang__) && __clang_major__ < 12
+#define __JULE_ATTRIBUTE_HOT
+#else
+#define __JULE_ATTRIBUTE_HOT __attribute__((hot))
+#endif
+
 #endif // ifndef __JULE_PLATFORM_HPP
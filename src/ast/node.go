@@ -167,6 +167,7 @@ type ExprData = any
 // Expression.
 type Expr struct {
 	Token lex.Token
+	End   lex.Token // Last token of expression.
 
 	// Possible types:
 	//  - *TupleExpr
@@ -382,6 +383,7 @@ type ScopeTree struct {
 	Unsafety bool
 	Deferred bool
 	Stmts    []NodeData // Statements.
+	End      lex.Token  // Closing brace of function bodies, zero for other scopes.
 }
 
 // ParamDecl.
@@ -407,6 +409,7 @@ type FnDecl struct {
 	Unsafety     bool
 	Public       bool
 	Cpp_linked   bool
+	C_fnptr      bool // C function pointer type.
 	Ident        string
 	Directives   []*Directive
 	Doc_comments *CommentGroup
@@ -420,11 +423,14 @@ type FnDecl struct {
 type VarDecl struct {
 	Scope        *ScopeTree // nil for global scopes
 	Token        lex.Token
+	Begin        lex.Token // First token of declaration.
+	End          lex.Token // Last token of declaration.
 	Ident        string
 	Cpp_linked   bool
 	Public       bool
 	Mutable      bool
 	Constant     bool
+	Directives   []*Directive
 	Doc_comments *CommentGroup
 	Kind         *TypeDecl // nil for auto-typed
 	Expr         *Expr
@@ -507,6 +513,7 @@ type TypeAliasDecl struct {
 	Ident        string
 	Kind         *TypeDecl
 	Doc_comments *CommentGroup
+	Generics     []*GenericDecl // Only supported by cpp-linked type aliases.
 }
 
 // Case of match-case.
@@ -534,8 +541,9 @@ type UseDecl struct {
 	Link_path  string // Use declaration path string.
 	Full       bool   // Full implicit import.
 	Selected   []lex.Token
-	Cpp_linked bool // Cpp header use declaration.
-	Std        bool // Standard package use declaration.
+	Cpp_linked bool   // Cpp header use declaration.
+	Cpp_flags  string // Compiler flags of cpp source use declaration.
+	Std        bool   // Standard package use declaration.
 }
 
 // Enum item.
@@ -555,6 +563,7 @@ type EnumDecl struct {
 	Ident        string
 	Kind         *TypeDecl
 	Items        []*EnumItemDecl
+	Directives   []*Directive
 	Doc_comments *CommentGroup
 }
 
@@ -587,6 +596,7 @@ type TraitDecl struct {
 	Token        lex.Token
 	Ident        string
 	Public       bool
+	Directives   []*Directive
 	Doc_comments *CommentGroup
 	Methods      []*FnDecl
 }
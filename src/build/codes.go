@@ -0,0 +1,270 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+// Public codes of error and warning messages.
+// Codes are stable, never reassigned or reused for another error.
+// New errors takes next free code.
+var CODES = map[string]string{
+	`stdlib_not_exist`:                         `J0001`,
+	`file_not_useable`:                         `J0002`,
+	`file_not_jule`:                            `J0003`,
+	`no_entry_point`:                           `J0004`,
+	`duplicated_ident`:                         `J0005`,
+	`extra_closed_parentheses`:                 `J0006`,
+	`extra_closed_braces`:                      `J0007`,
+	`extra_closed_brackets`:                    `J0008`,
+	`wait_close_parentheses`:                   `J0009`,
+	`wait_close_brace`:                         `J0010`,
+	`wait_close_bracket`:                       `J0011`,
+	`expected_parentheses_close`:               `J0012`,
+	`expected_brace_close`:                     `J0013`,
+	`expected_bracket_close`:                   `J0014`,
+	`body_not_exist`:                           `J0015`,
+	`operator_overflow`:                        `J0016`,
+	`incompatible_types`:                       `J0017`,
+	`operator_not_for_juletype`:                `J0018`,
+	`operator_not_for_float`:                   `J0019`,
+	`operator_not_for_int`:                     `J0020`,
+	`operator_not_for_uint`:                    `J0021`,
+	`ident_not_exist`:                          `J0022`,
+	`not_function_call`:                        `J0023`,
+	`argument_overflow`:                        `J0024`,
+	`fn_have_ret`:                              `J0025`,
+	`fn_have_parameters`:                       `J0026`,
+	`fn_is_unsafe`:                             `J0027`,
+	`require_ret_expr`:                         `J0028`,
+	`void_function_ret_expr`:                   `J0029`,
+	`bitshift_must_unsigned`:                   `J0030`,
+	`logical_not_bool`:                         `J0031`,
+	`assign_const`:                             `J0032`,
+	`assign_require_lvalue`:                    `J0033`,
+	`assign_type_not_support_value`:            `J0034`,
+	`invalid_token`:                            `J0035`,
+	`invalid_syntax`:                           `J0036`,
+	`invalid_type`:                             `J0037`,
+	`invalid_numeric_range`:                    `J0038`,
+	`invalid_operator`:                         `J0039`,
+	`invalid_expr_unary_operator`:              `J0040`,
+	`invalid_escape_sequence`:                  `J0041`,
+	`invalid_type_source`:                      `J0042`,
+	`invalid_preprocessor`:                     `J0043`,
+	`invalid_pragma_directive`:                 `J0044`,
+	`invalid_type_for_const`:                   `J0045`,
+	`invalid_value_for_key`:                    `J0046`,
+	`invalid_expr`:                             `J0047`,
+	`invalid_cpp_ext`:                          `J0048`,
+	`invalid_label`:                            `J0049`,
+	`missing_autotype_value`:                   `J0050`,
+	`missing_type`:                             `J0051`,
+	`missing_expr`:                             `J0052`,
+	`missing_block_comment`:                    `J0053`,
+	`missing_rune_end`:                         `J0054`,
+	`missing_ret`:                              `J0055`,
+	`missing_string_end`:                       `J0056`,
+	`missing_multi_ret`:                        `J0057`,
+	`missing_multi_assign_idents`:              `J0058`,
+	`missing_use_path`:                         `J0059`,
+	`missing_pragma_directive`:                 `J0060`,
+	`missing_goto_label`:                       `J0061`,
+	`missing_expr_for`:                         `J0062`,
+	`missing_generics`:                         `J0063`,
+	`missing_receiver`:                         `J0064`,
+	`missing_function_parentheses`:             `J0065`,
+	`expr_not_const`:                           `J0066`,
+	`nil_for_autotype`:                         `J0067`,
+	`void_for_autotype`:                        `J0068`,
+	`rune_empty`:                               `J0069`,
+	`rune_overflow`:                            `J0070`,
+	`not_supports_indexing`:                    `J0071`,
+	`not_supports_slicing`:                     `J0072`,
+	`already_const`:                            `J0073`,
+	`already_variadic`:                         `J0074`,
+	`already_reference`:                        `J0075`,
+	`duplicate_use_decl`:                       `J0076`,
+	`ignore_ident`:                             `J0077`,
+	`overflow_multi_assign_idents`:             `J0078`,
+	`overflow_ret`:                             `J0079`,
+	`break_at_out_of_valid_scope`:              `J0080`,
+	`continue_at_out_of_valid_scope`:           `J0081`,
+	`iter_while_require_bool_expr`:             `J0082`,
+	`iter_range_require_enumerable_expr`:       `J0083`,
+	`much_range_vars`:                          `J0084`,
+	`if_require_bool_expr`:                     `J0085`,
+	`else_have_expr`:                           `J0086`,
+	`variadic_parameter_not_last`:              `J0087`,
+	`variadic_with_non_variadicable`:           `J0088`,
+	`more_args_with_variadiced`:                `J0089`,
+	`type_not_supports_casting`:                `J0090`,
+	`type_not_supports_casting_to`:             `J0091`,
+	`use_at_content`:                           `J0092`,
+	`use_not_found`:                            `J0093`,
+	`used_package_has_errors`:                  `J0094`,
+	`def_not_support_pub`:                      `J0095`,
+	`obj_not_support_sub_fields`:               `J0096`,
+	`obj_have_not_ident`:                       `J0097`,
+	`type_not_support_sub_fields`:              `J0098`,
+	`type_have_not_ident`:                      `J0099`,
+	`doc_couldnt_generated`:                    `J0100`,
+	`expr_not_func_call`:                       `J0101`,
+	`label_exist`:                              `J0102`,
+	`label_not_exist`:                          `J0103`,
+	`goto_jumps_declarations`:                  `J0104`,
+	`fn_not_has_parameter`:                     `J0105`,
+	`already_has_expr`:                         `J0106`,
+	`argument_must_target_to_field`:            `J0107`,
+	`overflow_limits`:                          `J0108`,
+	`generics_overflow`:                        `J0109`,
+	`has_generics`:                             `J0110`,
+	`not_has_generics`:                         `J0111`,
+	`type_not_supports_generics`:               `J0112`,
+	`divide_by_zero`:                           `J0113`,
+	`trait_have_not_ident`:                     `J0114`,
+	`not_impl_trait_def`:                       `J0115`,
+	`dynamic_type_annotation_failed`:           `J0116`,
+	`fallthrough_wrong_use`:                    `J0117`,
+	`fallthrough_into_final_case`:              `J0118`,
+	`unsafe_behavior_at_out_of_unsafe_scope`:   `J0119`,
+	`ref_method_used_with_not_ref_instance`:    `J0120`,
+	`method_as_anonymous_fn`:                   `J0121`,
+	`genericed_fn_as_anonymous_fn`:             `J0122`,
+	`illegal_cycle_refers_itself`:              `J0123`,
+	`illegal_cross_cycle`:                      `J0124`,
+	`assignment_to_non_mut`:                    `J0125`,
+	`assignment_non_mut_to_mut`:                `J0126`,
+	`ret_with_mut_typed_non_mut`:               `J0127`,
+	`mutable_operation_on_immutable`:           `J0128`,
+	`trait_has_reference_parametered_function`: `J0129`,
+	`enum_have_not_field`:                      `J0130`,
+	`enum_not_supports_as_generic`:             `J0131`,
+	`duplicate_match_type`:                     `J0132`,
+	`cpp_linked_variable_has_expr`:             `J0133`,
+	`cpp_linked_variable_is_const`:             `J0134`,
+	`const_var_not_have_expr`:                  `J0135`,
+	`ref_refs_ref`:                             `J0136`,
+	`ref_refs_ptr`:                             `J0137`,
+	`ref_refs_array`:                           `J0138`,
+	`ref_refs_enum`:                            `J0139`,
+	`ptr_points_ref`:                           `J0140`,
+	`ptr_points_enum`:                          `J0141`,
+	`missing_expr_for_unary`:                   `J0142`,
+	`invalid_op_for_unary`:                     `J0143`,
+	`use_decl_at_body`:                         `J0144`,
+	`pass_directive_at_body`:                   `J0145`,
+	`pwd_cannot_set`:                           `J0146`,
+	`array_auto_sized`:                         `J0147`,
+	`namespace_not_exist`:                      `J0148`,
+	`impl_base_not_exist`:                      `J0149`,
+	`impl_dest_not_exist`:                      `J0150`,
+	`struct_already_have_ident`:                `J0151`,
+	`unsafe_ptr_indexing`:                      `J0152`,
+	`method_has_generic_with_same_ident`:       `J0153`,
+	`tuple_assign_to_single`:                   `J0154`,
+	`missing_compile_path`:                     `J0155`,
+	`array_size_is_not_int`:                    `J0156`,
+	`array_size_is_negative`:                   `J0157`,
+	`builtin_as_anonymous_fn`:                  `J0158`,
+	`type_case_has_not_valid_expr`:             `J0159`,
+	`illegal_impl_out_of_package`:              `J0160`,
+	`method_not_invoked`:                       `J0161`,
+	`duplicated_import_selection`:              `J0162`,
+	`ident_is_not_accessible`:                  `J0163`,
+	`invalid_stmt_for_next`:                    `J0164`,
+	`modulo_with_not_int`:                      `J0165`,
+	`pkg_illegal_cycle_refers_itself`:          `J0166`,
+	`pkg_illegal_cross_cycle`:                  `J0167`,
+	`refers_to`:                                `J0168`,
+	`no_file_in_entry_package`:                 `J0169`,
+	`no_member_in_enum`:                        `J0170`,
+	`type_is_not_derives`:                      `J0171`,
+	`clone_with_mut`:                           `J0172`,
+	`clone_non_lvalue`:                         `J0173`,
+	`clone_immut_struct`:                       `J0174`,
+	`internal_type_not_supports_clone`:         `J0175`,
+	`type_not_compatible_for_derive`:           `J0176`,
+	`pass_directive_not_starts_with_dash`:      `J0177`,
+	`derive_illegal_cycle_refers_itself`:       `J0178`,
+	`derive_illegal_cross_cycle`:               `J0179`,
+	`invalid_expr_for_binop`:                   `J0180`,
+	`cpp_linked_struct_for_ref`:                `J0181`,
+	`export_generic_fn`:                        `J0182`,
+	`export_method`:                            `J0183`,
+	`export_cpp_linked`:                        `J0184`,
+	`export_special_fn`:                        `J0185`,
+	`export_variadic`:                          `J0186`,
+	`export_invalid_ident`:                     `J0187`,
+	`export_duplicated_ident`:                  `J0188`,
+	`export_incompatible_type`:                 `J0189`,
+	`pass_denied_by_policy`:                    `J0190`,
+	`use_cpp_denied_by_policy`:                 `J0191`,
+	`cpp_flag_denied_by_policy`:                `J0192`,
+	`link_directive_missing_lib`:               `J0193`,
+	`link_directive_invalid_lib`:               `J0194`,
+	`cpp_flags_for_non_source`:                 `J0195`,
+	`cpp_flag_not_starts_with_dash`:            `J0196`,
+	`generic_type_alias_not_cpp_linked`:        `J0197`,
+	`c_fnptr_variadic`:                         `J0198`,
+	`c_fnptr_incompatible_type`:                `J0199`,
+	`c_fnptr_not_global_fn`:                    `J0200`,
+	`invalid_repr_directive`:                   `J0201`,
+	`invalid_align_directive`:                  `J0202`,
+	`layout_directive_for_cpp_linked`:          `J0203`,
+	`layout_directive_for_generic_struct`:      `J0204`,
+	`repr_c_implements_trait`:                  `J0205`,
+	`repr_c_ref_self`:                          `J0206`,
+	`repr_c_incompatible_field`:                `J0207`,
+	`repr_c_heap_alloc`:                        `J0208`,
+	`packed_ref_field`:                         `J0209`,
+	`packed_incompatible_field`:                `J0210`,
+	`thread_local_const`:                       `J0211`,
+	`thread_local_cpp_linked`:                  `J0212`,
+	`directive_for_non_fn`:                     `J0213`,
+	`fn_attribute_for_cpp_linked`:              `J0214`,
+	`inline_entry_point`:                       `J0215`,
+	`conflicting_fn_attributes`:                `J0216`,
+	`did_you_mean`:                             `J0217`,
+	`allow_directive_missing_check`:            `J0218`,
+	`allow_directive_invalid_check`:            `J0219`,
+	`layout_directive_without_repr_c`:          `J0240`,
+	`repr_c_field_ptr`:                         `J0241`,
+	`link_flag_denied_by_policy`:               `J0242`,
+
+	// Warnings.
+	`deprecated`:              `J0220`,
+	`deprecated_with_message`: `J0221`,
+	`declared_here`:           `J0222`,
+	`previous_declaration`:    `J0223`,
+	`instantiated_here`:       `J0224`,
+	`required_by_trait`:       `J0225`,
+	`implemented_here`:        `J0226`,
+	`declared_but_not_used`:   `J0227`,
+	`unused_import`:           `J0228`,
+	`unused_import_selection`: `J0229`,
+	`unreachable_code`:        `J0230`,
+	`unreachable_case`:        `J0231`,
+	`self_assign`:             `J0232`,
+	`self_compare`:            `J0233`,
+	`const_cond`:              `J0234`,
+	`shadows_var`:             `J0235`,
+	`lossy_conv`:              `J0236`,
+	`ignored_error`:           `J0237`,
+	`defer_in_loop`:           `J0238`,
+	`unused_allow`:            `J0239`,
+}
+
+// Returns public code of error or warning message by key.
+// Returns empty string if key has not code.
+func Code(key string) string { return CODES[key] }
+
+// Returns key of error message by public code.
+// Returns false if code is not exist.
+func Key_of_code(code string) (string, bool) {
+	for key, c := range CODES {
+		if c == code {
+			return key, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"strings"
+	"testing"
+)
+
+// Checks message has public code and code has embedded explanation.
+func check_code(t *testing.T, key string) {
+	code := Code(key)
+	if code == "" {
+		t.Errorf("message %q has not code", key)
+		return
+	}
+
+	bytes, err := explanations.ReadFile("explain/" + code + ".txt")
+	if err != nil {
+		t.Errorf("code %s of message %q has not explanation", code, key)
+		return
+	}
+	if !strings.HasPrefix(string(bytes), code+": ") {
+		t.Errorf("explanation of code %s is not starts with code", code)
+	}
+}
+
+func Test_codes(t *testing.T) {
+	for key := range ERRORS {
+		check_code(t, key)
+	}
+	for key := range WARNINGS {
+		check_code(t, key)
+	}
+}
+
+func Test_codes_unique(t *testing.T) {
+	keys := map[string]string{}
+	for key, code := range CODES {
+		_, ok := default_message(key)
+		if !ok {
+			t.Errorf("code %s has unknown message key %q", code, key)
+		}
+
+		prev, ok := keys[code]
+		if ok {
+			t.Errorf("code %s is used by messages %q and %q", code, prev, key)
+		}
+		keys[code] = key
+	}
+}
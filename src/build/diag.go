@@ -0,0 +1,309 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Formats of rendered logs.
+const DIAG_FORMAT_RICH = "rich"   // Logs with source snippets.
+const DIAG_FORMAT_SHORT = "short" // Single line for each log.
+const DIAG_FORMAT_JSON = "json"   // JSON array of logs.
+
+// ANSI escape codes of colored logs.
+const _ANSI_RESET = "\033[0m"
+const _ANSI_BOLD = "\033[1m"
+const _ANSI_RED = "\033[1;31m"
+const _ANSI_MAGENTA = "\033[1;35m"
+const _ANSI_CYAN = "\033[1;36m"
+const _ANSI_BLUE = "\033[1;34m"
+
+// Column width of tab characters, must be same with lexer.
+const _TAB_WIDTH = 4
+
+// Renderer of logs with source snippets.
+type Renderer struct {
+	Color bool
+	lines map[string][]string // Lines of source files by path.
+}
+
+// Returns new renderer.
+func New_renderer(color bool) *Renderer {
+	return &Renderer{
+		Color: color,
+		lines: map[string][]string{},
+	}
+}
+
+// Reports whether file is terminal.
+func Is_tty(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Returns line of source file by row.
+// Returns false if line is not exist.
+func (r *Renderer) get_line(path string, row int) (string, bool) {
+	lines, ok := r.lines[path]
+	if !ok {
+		bytes, err := os.ReadFile(path)
+		if err == nil {
+			lines = strings.Split(string(bytes), "\n")
+		}
+		r.lines[path] = lines
+	}
+
+	if row < 1 || row > len(lines) {
+		return "", false
+	}
+	line := strings.TrimSuffix(lines[row-1], "\r")
+	return strings.ReplaceAll(line, "\t", strings.Repeat(" ", _TAB_WIDTH)), true
+}
+
+func (r *Renderer) paint(code string, s string) string {
+	if !r.Color {
+		return s
+	}
+	return code + s + _ANSI_RESET
+}
+
+// Returns severity and color of log.
+func get_severity(l *Log) (string, string) {
+	switch l.Type {
+	case WARN:
+		return "warning", _ANSI_MAGENTA
+
+	case NOTE:
+		return "note", _ANSI_CYAN
+
+	default:
+		return "error", _ANSI_RED
+	}
+}
+
+// Returns marker of range in line.
+// Column and length are byte based, marker is rune based.
+func get_marker(line string, column int, length int, mark byte) (string, string) {
+	start := column - 1
+	if start > len(line) {
+		start = len(line)
+	}
+	end := start + length
+	if length < 1 || end > len(line) {
+		end = start + 1
+		if end > len(line) {
+			end = len(line)
+		}
+	}
+
+	indent := strings.Repeat(" ", utf8.RuneCountInString(line[:start]))
+	n := utf8.RuneCountInString(line[start:end])
+	if n < 1 {
+		n = 1
+	}
+	return indent, strings.Repeat(string(mark), n)
+}
+
+// Renders header of log.
+func (r *Renderer) render_header(sb *strings.Builder, l *Log) {
+	severity, color := get_severity(l)
+	if l.Code != "" {
+		severity += "[" + l.Code + "]"
+	}
+
+	if l.Type == FLAT_ERR {
+		sb.WriteString(r.paint(color, severity+":"))
+		sb.WriteByte(' ')
+		sb.WriteString(l.Text)
+		return
+	}
+
+	sb.WriteString(r.paint(_ANSI_BOLD, l.Path+":"+strconv.Itoa(l.Row)+":"+strconv.Itoa(l.Column)))
+	sb.WriteByte(' ')
+	sb.WriteString(r.paint(color, severity+":"))
+	sb.WriteByte(' ')
+	sb.WriteString(l.Text)
+	if l.Class != "" {
+		sb.WriteString(" [-W" + l.Class + "]")
+	}
+}
+
+// Renders source lines of log with markers of log and spans.
+func (r *Renderer) render_snippet(sb *strings.Builder, l *Log) {
+	_, color := get_severity(l)
+
+	// Primary range is first, others are ordered by position.
+	spans := []Span{{Row: l.Row, Column: l.Column, Length: l.Length}}
+	spans = append(spans, l.Spans...)
+	sort.SliceStable(spans[1:], func(i, j int) bool {
+		si, sj := spans[1+i], spans[1+j]
+		return si.Row < sj.Row || si.Row == sj.Row && si.Column < sj.Column
+	})
+
+	rows := []int{}
+	for _, s := range spans {
+		exist := false
+		for _, row := range rows {
+			if row == s.Row {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			rows = append(rows, s.Row)
+		}
+	}
+	sort.Ints(rows)
+
+	width := len(strconv.Itoa(rows[len(rows)-1]))
+	gutter := r.paint(_ANSI_BLUE, strings.Repeat(" ", width)+" |")
+
+	sb.WriteByte('\n')
+	sb.WriteString(gutter)
+	for _, row := range rows {
+		line, ok := r.get_line(l.Path, row)
+		if !ok {
+			continue
+		}
+
+		num := strconv.Itoa(row)
+		sb.WriteByte('\n')
+		sb.WriteString(r.paint(_ANSI_BLUE, strings.Repeat(" ", width-len(num))+num+" |"))
+		sb.WriteByte(' ')
+		sb.WriteString(line)
+
+		for i, s := range spans {
+			if s.Row != row {
+				continue
+			}
+
+			mark, mark_color := byte('^'), color
+			if i > 0 {
+				mark, mark_color = '-', _ANSI_BLUE
+			}
+
+			indent, marker := get_marker(line, s.Column, s.Length, mark)
+			sb.WriteByte('\n')
+			sb.WriteString(gutter)
+			sb.WriteByte(' ')
+			sb.WriteString(indent)
+			if s.Label != "" {
+				marker += " " + s.Label
+			}
+			sb.WriteString(r.paint(mark_color, marker))
+		}
+	}
+}
+
+// Returns log with source snippet and attached notes.
+// Snippet is not rendered if source line is not readable.
+func (r *Renderer) Render(l Log) string {
+	var sb strings.Builder
+	r.render_header(&sb, &l)
+	if l.Type == FLAT_ERR {
+		return sb.String()
+	}
+
+	_, ok := r.get_line(l.Path, l.Row)
+	if ok {
+		r.render_snippet(&sb, &l)
+	}
+
+	for _, n := range l.Notes {
+		sb.WriteByte('\n')
+		sb.WriteString(r.Render(n))
+	}
+	return sb.String()
+}
+
+// JSON form of span.
+type _JsonSpan struct {
+	Row    int    `json:"row"`
+	Column int    `json:"column"`
+	Length int    `json:"length"`
+	Label  string `json:"label,omitempty"`
+}
+
+// JSON form of edit.
+type _JsonEdit struct {
+	Row        int    `json:"row"`
+	Column     int    `json:"column"`
+	End_row    int    `json:"end_row"`
+	End_column int    `json:"end_column"`
+	Text       string `json:"text"`
+}
+
+// JSON form of log.
+type _JsonLog struct {
+	Severity string      `json:"severity"`
+	Code     string      `json:"code,omitempty"`
+	Class    string      `json:"class,omitempty"`
+	Path     string      `json:"path,omitempty"`
+	Row      int         `json:"row,omitempty"`
+	Column   int         `json:"column,omitempty"`
+	Length   int         `json:"length,omitempty"`
+	Message  string      `json:"message"`
+	Spans    []_JsonSpan `json:"spans,omitempty"`
+	Notes    []_JsonLog  `json:"notes,omitempty"`
+	Edits    []_JsonEdit `json:"edits,omitempty"`
+}
+
+func to_json_log(l *Log) _JsonLog {
+	severity, _ := get_severity(l)
+	jl := _JsonLog{
+		Severity: severity,
+		Code:     l.Code,
+		Class:    l.Class,
+		Path:     l.Path,
+		Row:      l.Row,
+		Column:   l.Column,
+		Length:   l.Length,
+		Message:  l.Text,
+	}
+
+	for _, s := range l.Spans {
+		jl.Spans = append(jl.Spans, _JsonSpan{
+			Row:    s.Row,
+			Column: s.Column,
+			Length: s.Length,
+			Label:  s.Label,
+		})
+	}
+
+	for i := range l.Notes {
+		jl.Notes = append(jl.Notes, to_json_log(&l.Notes[i]))
+	}
+
+	for _, e := range l.Edits {
+		jl.Edits = append(jl.Edits, _JsonEdit{
+			Row:        e.Row,
+			Column:     e.Column,
+			End_row:    e.End_row,
+			End_column: e.End_column,
+			Text:       e.Text,
+		})
+	}
+	return jl
+}
+
+// Returns logs as JSON array.
+func Render_json(logs []Log) string {
+	jlogs := make([]_JsonLog, len(logs))
+	for i := range logs {
+		jlogs[i] = to_json_log(&logs[i])
+	}
+
+	bytes, err := json.MarshalIndent(jlogs, "", "  ")
+	if err != nil {
+		panic("logs could not rendered as JSON: " + err.Error())
+	}
+	return string(bytes)
+}
@@ -12,18 +12,59 @@ const DIRECTIVE_CDEF = "cdef"       // Directive: jule:cdef
 const DIRECTIVE_TYPEDEF = "typedef" // Directive: jule:typedef
 const DIRECTIVE_DERIVE = "derive"   // Directive: jule:derive
 const DIRECTIVE_PASS = "pass"       // Directive: jule:pass
+const DIRECTIVE_EXPORT = "export"   // Directive: jule:export
+const DIRECTIVE_LINK = "link"       // Directive: jule:link
+const DIRECTIVE_REPR = "repr"       // Directive: jule:repr
+const DIRECTIVE_PACKED = "packed"   // Directive: jule:packed
+const DIRECTIVE_ALIGN = "align"     // Directive: jule:align
+
+const DIRECTIVE_THREAD_LOCAL = "thread_local" // Directive: jule:thread_local
+const DIRECTIVE_DEPRECATED = "deprecated"     // Directive: jule:deprecated
+const DIRECTIVE_ALLOW = "allow"               // Directive: jule:allow
+
+// Function attribute directives.
+const DIRECTIVE_INLINE = "inline"     // Directive: jule:inline
+const DIRECTIVE_NOINLINE = "noinline" // Directive: jule:noinline
+const DIRECTIVE_COLD = "cold"         // Directive: jule:cold
+const DIRECTIVE_HOT = "hot"           // Directive: jule:hot
 
 const DERIVE_CLONE = "Clone"
 
+// C-compatible layout for repr directive.
+const REPR_C = "C"
+
 // List of all directives.
 var DIRECTIVES = [...]string{
 	DIRECTIVE_CDEF,
 	DIRECTIVE_TYPEDEF,
 	DIRECTIVE_DERIVE,
 	DIRECTIVE_PASS,
+	DIRECTIVE_EXPORT,
+	DIRECTIVE_LINK,
+	DIRECTIVE_REPR,
+	DIRECTIVE_PACKED,
+	DIRECTIVE_ALIGN,
+	DIRECTIVE_THREAD_LOCAL,
+	DIRECTIVE_DEPRECATED,
+	DIRECTIVE_ALLOW,
+	DIRECTIVE_INLINE,
+	DIRECTIVE_NOINLINE,
+	DIRECTIVE_COLD,
+	DIRECTIVE_HOT,
 }
 
 // Reports whether directive is top-directive.
 func Is_top_directive(directive string) bool {
-	return directive == DIRECTIVE_PASS
+	return directive == DIRECTIVE_PASS || directive == DIRECTIVE_LINK
+}
+
+// Reports whether directive is function attribute directive.
+func Is_fn_attribute_directive(directive string) bool {
+	switch directive {
+	case DIRECTIVE_INLINE, DIRECTIVE_NOINLINE, DIRECTIVE_COLD, DIRECTIVE_HOT:
+		return true
+
+	default:
+		return false
+	}
 }
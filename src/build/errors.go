@@ -108,7 +108,6 @@ var ERRORS = map[string]string{
 	`type_not_support_sub_fields`:              `type @ is not supports sub fields`,
 	`type_have_not_ident`:                      `type @ is not have sub field in this identifier: @`,
 	`doc_couldnt_generated`:                    `@: documentation could not generated because Jule source code has an errors`,
-	`declared_but_not_used`:                    `@ declared but not used`,
 	`expr_not_func_call`:                       `statement must have function call expression`,
 	`label_exist`:                              `label is already exist in this identifier: @`,
 	`label_not_exist`:                          `not exist any label in this identifier: @`,
@@ -190,11 +189,52 @@ var ERRORS = map[string]string{
 	`derive_illegal_cross_cycle`:               "illegal cross cycle for \"@\" derive;\n@",
 	`invalid_expr_for_binop`:                   `invalid expression used for binary operation`,
 	`cpp_linked_struct_for_ref`:                `cpp-linked structures cannot supports reference counting`,
+	`export_generic_fn`:                        `exported functions cannot have generics`,
+	`export_method`:                            `methods cannot be exported`,
+	`export_cpp_linked`:                        `cpp-linked functions cannot be exported`,
+	`export_special_fn`:                        `entry point and initializer functions cannot be exported`,
+	`export_variadic`:                          `exported functions cannot have variadic parameters`,
+	`export_invalid_ident`:                     `invalid identifier for exported symbol: @`,
+	`export_duplicated_ident`:                  `exported symbol already exist in this identifier: @`,
+	`export_incompatible_type`:                 `type "@" is not compatible with C ABI`,
+	`pass_denied_by_policy`:                    `pass flag is not allowed by policy: @ (package: @)`,
+	`use_cpp_denied_by_policy`:                 `cpp use declaration is not allowed by policy: @ (package: @)`,
+	`cpp_flag_denied_by_policy`:                `cpp source flag is not allowed by policy: @ (package: @)`,
+	`link_directive_missing_lib`:               `library name is missing for link directive`,
+	`link_directive_invalid_lib`:               `invalid library name for link directive: @`,
+	`cpp_flags_for_non_source`:                 `compiler flags are only allowed for cpp source files`,
+	`cpp_flag_not_starts_with_dash`:            `compiler flag must be start with dash: @`,
+	`generic_type_alias_not_cpp_linked`:        `only cpp-linked type aliases can have generics`,
+	`c_fnptr_variadic`:                         `C function pointers cannot have variadic parameters`,
+	`c_fnptr_incompatible_type`:                `type "@" is not compatible with C function pointers`,
+	`c_fnptr_not_global_fn`:                    `only global functions can be used as C function pointer`,
+	`invalid_repr_directive`:                   `invalid representation for repr directive: @`,
+	`invalid_align_directive`:                  `alignment must be power of two: @`,
+	`layout_directive_for_cpp_linked`:          `layout directives cannot be used for cpp-linked structures`,
+	`layout_directive_for_generic_struct`:      `genericed structures cannot have C layout or packed`,
+	`repr_c_implements_trait`:                  `structure with C layout cannot implement traits: @`,
+	`repr_c_ref_self`:                          `methods of structure with C layout cannot have reference receiver: @`,
+	`repr_c_incompatible_field`:                `field "@" has type "@" that is not compatible with C layout`,
+	`repr_c_heap_alloc`:                        `structure with C layout cannot be allocated as reference: @`,
+	`packed_ref_field`:                         `packed structures cannot have reference fields: @`,
+	`packed_incompatible_field`:                `field "@" has type "@" that cannot be packed`,
+	`thread_local_const`:                       `constant variables cannot be thread-local: @`,
+	`thread_local_cpp_linked`:                  `cpp-linked variables cannot be thread-local: @`,
+	`directive_for_non_fn`:                     `@ directive can be used for functions only`,
+	`fn_attribute_for_cpp_linked`:              `@ directive cannot be used for cpp-linked functions`,
+	`inline_entry_point`:                       `entry point cannot be inline`,
+	`conflicting_fn_attributes`:                `@ and @ directives cannot be used together`,
+	`did_you_mean`:                             `did you mean @?`,
+	`allow_directive_missing_check`:            `check is missing for allow directive`,
+	`allow_directive_invalid_check`:            `invalid check for allow directive: @`,
+	`layout_directive_without_repr_c`:          `@ directive requires repr C directive`,
+	`repr_c_field_ptr`:                         `cannot take pointer of integer field of structure with C layout: @`,
+	`link_flag_denied_by_policy`:               `link flag is not allowed by policy: @ (library: @, package: @)`,
 }
 
 // Returns formatted error message by key and args.
 func Errorf(key string, args ...any) string {
-	fmt := ERRORS[key]
+	fmt := translate(key, ERRORS[key])
 	return apply_fmt(fmt, args...)
 }
 
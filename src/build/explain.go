@@ -0,0 +1,37 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"embed"
+	"strings"
+)
+
+// Explanations of errors and warnings, one file for each public code.
+//
+//go:embed explain/*.txt
+var explanations embed.FS
+
+// Returns explanation of error or warning by public code.
+// Code is case insensitive, "J" prefix and leading zeros are optional.
+// Returns false if code is not exist or has not explanation.
+func Explain(code string) (string, bool) {
+	code = strings.TrimPrefix(strings.ToUpper(code), "J")
+	if len(code) < 4 {
+		code = strings.Repeat("0", 4-len(code)) + code
+	}
+	code = "J" + code
+
+	_, ok := Key_of_code(code)
+	if !ok {
+		return "", false
+	}
+
+	bytes, err := explanations.ReadFile("explain/" + code + ".txt")
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(bytes)), true
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"sort"
+	"strings"
+)
+
+// Byte range of edit in source text.
+type _EditRange struct {
+	start int
+	end   int
+	text  string
+}
+
+// Reports whether ranges overlap.
+// Insertions at same offset are overlapping.
+func (r *_EditRange) overlaps(r2 *_EditRange) bool {
+	return r.start == r2.start || (r.start < r2.end && r2.start < r.end)
+}
+
+// Returns byte offsets of beginning of lines.
+func line_offsets(text string) []int {
+	offsets := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// Returns byte offset of position in text.
+// Columns of tab characters are same with lexer.
+// Returns false if position is not exist.
+func offset_of(text string, lines []int, row int, column int) (int, bool) {
+	if row < 1 || row > len(lines) {
+		return 0, false
+	}
+
+	i := lines[row-1]
+	col := 1
+	for col < column {
+		if i >= len(text) || text[i] == '\n' {
+			return 0, false
+		}
+		if text[i] == '\t' {
+			col += _TAB_WIDTH
+		} else {
+			col++
+		}
+		i++
+	}
+	return i, col == column
+}
+
+// Extends removal to whole lines if there is no other content in lines.
+func extend_removal(text string, r *_EditRange) {
+	start := strings.LastIndexByte(text[:r.start], '\n') + 1
+	end := strings.IndexByte(text[r.end:], '\n')
+	if end == -1 {
+		end = len(text)
+	} else {
+		end += r.end + 1
+	}
+
+	if strings.TrimSpace(text[start:r.start]) == "" && strings.TrimSpace(text[r.end:end]) == "" {
+		r.start = start
+		r.end = end
+	}
+}
+
+// Reports whether fixes have same edits.
+func is_same_fix(f1 []Edit, f2 []Edit) bool {
+	if len(f1) != len(f2) {
+		return false
+	}
+	for i := range f1 {
+		if f1[i] != f2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Applies fixes to source text, edits of each fix are applied together.
+// Fixes are skipped if have invalid position or overlap with previous fixes.
+// Identical fixes are applied once.
+// Removals are extended to whole lines if removed range is the only content of lines.
+// Returns fixed text and count of applied fixes.
+func Apply_fixes(text string, fixes [][]Edit) (string, int) {
+	lines := line_offsets(text)
+	var ranges []_EditRange
+	applied := 0
+
+fixes:
+	for i, fix := range fixes {
+		if len(fix) == 0 {
+			continue
+		}
+		for _, prev := range fixes[:i] {
+			if is_same_fix(prev, fix) {
+				continue fixes
+			}
+		}
+
+		var fix_ranges []_EditRange
+		for _, e := range fix {
+			start, ok := offset_of(text, lines, e.Row, e.Column)
+			if !ok {
+				continue fixes
+			}
+			end, ok := offset_of(text, lines, e.End_row, e.End_column)
+			if !ok || end < start {
+				continue fixes
+			}
+
+			r := _EditRange{start: start, end: end, text: e.Text}
+			if r.text == "" && r.end > r.start {
+				extend_removal(text, &r)
+			}
+
+			for i := range ranges {
+				if r.overlaps(&ranges[i]) {
+					continue fixes
+				}
+			}
+			for i := range fix_ranges {
+				if r.overlaps(&fix_ranges[i]) {
+					continue fixes
+				}
+			}
+			fix_ranges = append(fix_ranges, r)
+		}
+
+		ranges = append(ranges, fix_ranges...)
+		applied++
+	}
+
+	// Apply from end to keep offsets of previous ranges.
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start > ranges[j].start })
+	for _, r := range ranges {
+		text = text[:r.start] + r.text + text[r.end:]
+	}
+	return text, applied
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"embed"
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default language of messages.
+// Messages of default language are ERRORS and WARNINGS.
+const LANG_DEFAULT = "en"
+
+// Language of messages.
+// Selected by LANG environment variable if empty.
+// Sets by command-line inputs.
+var LANG = ""
+
+// Path of message override file.
+// Messages of file are used over messages of language.
+// Not used if empty.
+// Sets by command-line inputs.
+var LANG_FILE = ""
+
+// Message catalogs, one file for each language.
+// Catalogs are JSON objects of messages by keys of ERRORS and WARNINGS.
+//
+//go:embed locale/*.json
+var catalogs embed.FS
+
+// Translated messages by key.
+// Messages which are not translated falls back to default language.
+var messages = map[string]string{}
+
+// Returns language of LANG environment variable such as "tr" for "tr_TR.UTF-8".
+// Returns default language if variable is not set or is POSIX locale.
+func Env_lang() string {
+	lang := os.Getenv("LANG")
+	i := strings.IndexAny(lang, "_.@")
+	if i >= 0 {
+		lang = lang[:i]
+	}
+	lang = strings.ToLower(lang)
+	if lang == "" || lang == "c" || lang == "posix" {
+		return LANG_DEFAULT
+	}
+	return lang
+}
+
+// Reports whether language has catalog.
+func Is_lang(lang string) bool {
+	if lang == LANG_DEFAULT {
+		return true
+	}
+	_, err := catalogs.ReadFile("locale/" + lang + ".json")
+	return err == nil
+}
+
+// Returns all supported languages.
+func Langs() []string {
+	langs := []string{LANG_DEFAULT}
+	entries, _ := catalogs.ReadDir("locale")
+	for _, entry := range entries {
+		langs = append(langs, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// Loads messages of LANG and LANG_FILE.
+// Falls back to default language if LANG is empty and
+// language of environment has not catalog.
+// Returns problems of catalogs, messages of problems are not used.
+func Load_messages() []string {
+	messages = map[string]string{}
+
+	lang := LANG
+	if lang == "" {
+		lang = Env_lang()
+		if !Is_lang(lang) {
+			lang = LANG_DEFAULT
+		}
+	}
+
+	var problems []string
+	if lang != LANG_DEFAULT {
+		path := "locale/" + lang + ".json"
+		bytes, err := catalogs.ReadFile(path)
+		if err != nil {
+			return []string{"unsupported language: " + lang}
+		}
+		problems = load_catalog(path, bytes)
+	}
+
+	if LANG_FILE != "" {
+		bytes, err := os.ReadFile(LANG_FILE)
+		if err != nil {
+			return append(problems, err.Error())
+		}
+		problems = append(problems, load_catalog(LANG_FILE, bytes)...)
+	}
+
+	return problems
+}
+
+// Returns message of default language by key.
+func default_message(key string) (string, bool) {
+	msg, ok := ERRORS[key]
+	if ok {
+		return msg, true
+	}
+	msg, ok = WARNINGS[key]
+	return msg, ok
+}
+
+// Returns count of "@" placeholders.
+func count_fmt(fmt string) int { return strings.Count(fmt, "@") }
+
+// Loads messages of catalog over loaded messages.
+// Messages must have same number of placeholders with default language.
+func load_catalog(path string, bytes []byte) []string {
+	catalog := map[string]string{}
+	err := json.Unmarshal(bytes, &catalog)
+	if err != nil {
+		return []string{path + ": " + err.Error()}
+	}
+
+	var problems []string
+	for key, msg := range catalog {
+		def, ok := default_message(key)
+		switch {
+		case !ok:
+			problems = append(problems, path+": unknown message key: "+key)
+
+		case count_fmt(msg) != count_fmt(def):
+			problems = append(problems, path+": message \""+key+"\" has "+
+				strconv.Itoa(count_fmt(msg))+" placeholders, expected "+
+				strconv.Itoa(count_fmt(def)))
+
+		default:
+			messages[key] = msg
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// Returns translated message by key, or def if message is not translated.
+func translate(key string, def string) string {
+	msg, ok := messages[key]
+	if ok {
+		return msg
+	}
+	return def
+}
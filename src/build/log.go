@@ -12,19 +12,45 @@ import (
 // Log types.
 const FLAT_ERR = 0 // Just text.
 const ERR = 1      // Column, row, path and text.
+const WARN = 2     // Column, row, path and text of warning.
+const NOTE = 3     // Column, row, path and text of note for previous log.
+
+// Span is a labeled range of source line.
+type Span struct {
+	Row    int
+	Column int
+	Length int // Length of range in columns, zero if unknown.
+	Label  string
+}
+
+// Edit is a machine-applicable fix that replaces range of source file with text.
+// Range is exclusive, end position is same with start position for insertions.
+type Edit struct {
+	Row        int
+	Column     int
+	End_row    int
+	End_column int
+	Text       string
+}
 
 // Log is a build log.
 type Log struct {
 	Type   uint8
 	Row    int
 	Column int
+	Length int // Length of logged token in columns, zero if unknown.
 	Path   string
 	Text   string
+	Class  string // Warning class, empty if log is not belongs to warning class.
+	Code   string // Public code of message, empty if log has not code.
+	Spans  []Span // Additional labeled ranges in the same file.
+	Notes  []Log  // Attached notes with their own positions.
+	Edits  []Edit // Machine-applicable fix in the same file, edits must be applied together.
 }
 
 func (l *Log) flat_err() string { return l.Text }
 
-func (l *Log) err() string {
+func (l *Log) positioned(severity string) string {
 	var log strings.Builder
 	log.WriteString(l.Path)
 	log.WriteByte(':')
@@ -32,16 +58,101 @@ func (l *Log) err() string {
 	log.WriteByte(':')
 	log.WriteString(strconv.Itoa(l.Column))
 	log.WriteByte(' ')
+	log.WriteString(severity)
+	log.WriteString(": ")
 	log.WriteString(l.Text)
+	if l.Class != "" {
+		log.WriteString(" [-W")
+		log.WriteString(l.Class)
+		log.WriteByte(']')
+	}
 	return log.String()
 }
 
-func (l Log) String() string {
+func (l *Log) err() string {
+	if l.Code != "" {
+		return l.positioned("error[" + l.Code + "]")
+	}
+	return l.positioned("error")
+}
+
+func (l *Log) warn() string {
+	if l.Code != "" {
+		return l.positioned("warning[" + l.Code + "]")
+	}
+	return l.positioned("warning")
+}
+
+func (l *Log) note() string { return l.positioned("note") }
+
+func (l *Log) str() string {
 	switch l.Type {
 	case FLAT_ERR:
 		return l.flat_err()
 	case ERR:
 		return l.err()
+	case WARN:
+		return l.warn()
+	case NOTE:
+		return l.note()
 	}
 	return ""
 }
+
+// Returns log with attached notes, each in separate line.
+func (l Log) String() string {
+	s := l.str()
+	for _, n := range l.Notes {
+		s += "\n" + n.String()
+	}
+	return s
+}
+
+// Reports whether log is error.
+func (l *Log) Is_err() bool { return l.Type == FLAT_ERR || l.Type == ERR }
+
+// Reports whether logs have same type, class, position and text.
+// Spans and notes are not compared.
+func (l *Log) Is_same(l2 *Log) bool {
+	return l.Type == l2.Type &&
+		l.Class == l2.Class &&
+		l.Row == l2.Row &&
+		l.Column == l2.Column &&
+		l.Path == l2.Path &&
+		l.Text == l2.Text
+}
+
+func plural(n int, word string) string {
+	s := strconv.Itoa(n) + " " + word
+	if n != 1 {
+		s += "s"
+	}
+	return s
+}
+
+// Returns summary of logs such as "1 error, 2 warnings".
+// Notes are not counted.
+// Returns empty string if there is no error or warning.
+func Summary(logs []Log) string {
+	errors := 0
+	warnings := 0
+	for _, l := range logs {
+		switch {
+		case l.Is_err():
+			errors++
+		case l.Type == WARN:
+			warnings++
+		}
+	}
+
+	switch {
+	case errors > 0 && warnings > 0:
+		return plural(errors, "error") + ", " + plural(warnings, "warning")
+	case errors > 0:
+		return plural(errors, "error")
+	case warnings > 0:
+		return plural(warnings, "warning")
+	default:
+		return ""
+	}
+}
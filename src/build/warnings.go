@@ -0,0 +1,118 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package build
+
+// Warning classes.
+const WARN_DEPRECATED = "deprecated" // Uses of deprecated definitions.
+const WARN_UNUSED = "unused"         // Unused variables, labels and type aliases.
+
+const WARN_UNUSED_IMPORT = "unused-import" // Unused use declarations and selections.
+const WARN_UNREACHABLE = "unreachable"     // Unreachable statements and match cases.
+const WARN_UNUSED_ALLOW = "unused-allow"   // Allow directives suppresses nothing.
+
+// Warning classes of vet checks.
+// Vet checks are not reported by default, enabled by vet command.
+const VET_SELF_ASSIGN = "self-assign"     // Assignment of variable to itself.
+const VET_SELF_COMPARE = "self-compare"   // Comparison of value with itself.
+const VET_CONST_COND = "const-cond"       // Constant conditions of if statements.
+const VET_SHADOW = "shadow"               // Variables shadows variables of parent scopes.
+const VET_LOSSY_CONV = "lossy-conv"       // Implicit integer conversions may lose precision.
+const VET_IGNORED_ERROR = "ignored-error" // Ignored results of functions returns error.
+const VET_DEFER_LOOP = "defer-loop"       // Deferred scopes inside of iterations.
+
+// List of all vet checks.
+var VET_CHECKS = [...]string{
+	VET_SELF_ASSIGN,
+	VET_SELF_COMPARE,
+	VET_CONST_COND,
+	VET_SHADOW,
+	VET_LOSSY_CONV,
+	VET_IGNORED_ERROR,
+	VET_DEFER_LOOP,
+}
+
+// Levels of warning classes.
+const WARN_LEVEL_OFF = 0  // Not reported.
+const WARN_LEVEL_WARN = 1 // Reported as warning.
+const WARN_LEVEL_ERR = 2  // Reported as error, fails build.
+
+// Levels of warning classes.
+// Unused definitions are errors by default as language requires.
+// Sets by command-line inputs.
+var WARN_LEVELS = map[string]int{
+	WARN_DEPRECATED: WARN_LEVEL_WARN,
+	WARN_UNUSED:     WARN_LEVEL_ERR,
+
+	WARN_UNUSED_IMPORT: WARN_LEVEL_WARN,
+	WARN_UNREACHABLE:   WARN_LEVEL_WARN,
+	WARN_UNUSED_ALLOW:  WARN_LEVEL_WARN,
+
+	VET_SELF_ASSIGN:   WARN_LEVEL_OFF,
+	VET_SELF_COMPARE:  WARN_LEVEL_OFF,
+	VET_CONST_COND:    WARN_LEVEL_OFF,
+	VET_SHADOW:        WARN_LEVEL_OFF,
+	VET_LOSSY_CONV:    WARN_LEVEL_OFF,
+	VET_IGNORED_ERROR: WARN_LEVEL_OFF,
+	VET_DEFER_LOOP:    WARN_LEVEL_OFF,
+}
+
+// Warnings.
+// Warnings are not fails build unless requested by compiler options.
+var WARNINGS = map[string]string{
+	`deprecated`:              `"@" is deprecated`,
+	`deprecated_with_message`: `"@" is deprecated: @`,
+	`declared_here`:           `"@" declared here`,
+	`previous_declaration`:    `previous declaration of "@" here`,
+	`instantiated_here`:       `required by instantiation of "@" here`,
+	`required_by_trait`:       `"@" required by trait "@" here`,
+	`implemented_here`:        `"@" implemented with different signature here`,
+	`declared_but_not_used`:   `@ declared but not used`,
+	`unused_import`:           `"@" imported but not used`,
+	`unused_import_selection`: `"@" selected from "@" but not used`,
+	`unreachable_code`:        `unreachable code`,
+	`unreachable_case`:        `unreachable match case`,
+	`self_assign`:             `"@" assigned to itself`,
+	`self_compare`:            `comparison of expression with itself is always @`,
+	`const_cond`:              `condition is always @`,
+	`shadows_var`:             `declaration of "@" shadows variable of parent scope`,
+	`lossy_conv`:              `implicit conversion from @ to @ may lose precision`,
+	`ignored_error`:           `result of "@" is ignored but may be error`,
+	`defer_in_loop`:           `deferred scope inside of iteration runs every iteration`,
+	`unused_allow`:            `"@" is allowed but never reported here`,
+}
+
+// Reports whether class is warning class.
+func Is_warn_class(class string) bool {
+	_, ok := WARN_LEVELS[class]
+	return ok
+}
+
+// Reports whether warning class is vet check.
+func Is_vet_check(class string) bool {
+	for _, check := range VET_CHECKS {
+		if check == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns level of warning class.
+func Warn_level(class string) int { return WARN_LEVELS[class] }
+
+// Makes all reported warning classes as error.
+func Warnings_as_errors() {
+	for class, level := range WARN_LEVELS {
+		if level == WARN_LEVEL_WARN {
+			WARN_LEVELS[class] = WARN_LEVEL_ERR
+		}
+	}
+}
+
+// Returns formatted warning message by key and args.
+func Warnf(key string, args ...any) string {
+	fmt := translate(key, WARNINGS[key])
+	return apply_fmt(fmt, args...)
+}
@@ -0,0 +1,436 @@
+// Package bindgen generates cpp-linked Jule declarations from C headers.
+// Supports plain C declarations: functions, structures with fields,
+// enumerations, typedefs, global variables and integer constant macros.
+// Primitive types are mapped based on LP64 data model.
+package bindgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/lex"
+)
+
+// Jule type of C's char.
+// C's char is distinct type in C++, so declared as cpp-linked type.
+const CHAR_TYPE = "cpp.char"
+
+// Jule types of standard C typedefs.
+// Pointers of these types are not supported except
+// the 8-bit and 16-bit integers, see PTR_STD_TYPEDEFS.
+var STD_TYPEDEFS = map[string]string{
+	"int8_t":    "i8",
+	"int16_t":   "i16",
+	"int32_t":   "i32",
+	"int64_t":   "i64",
+	"uint8_t":   "u8",
+	"uint16_t":  "u16",
+	"uint32_t":  "u32",
+	"uint64_t":  "u64",
+	"size_t":    "uint",
+	"ssize_t":   "int",
+	"ptrdiff_t": "int",
+	"intptr_t":  "int",
+	"uintptr_t": "uintptr",
+}
+
+// Standard C typedefs which are same type with Jule's type in C++.
+var PTR_STD_TYPEDEFS = map[string]bool{
+	"int8_t":   true,
+	"int16_t":  true,
+	"uint8_t":  true,
+	"uint16_t": true,
+}
+
+// Jule code generator of C header.
+type _Gen struct {
+	h         *_Header
+	char_used bool
+	unknowns  []string // Referenced but not declared types.
+}
+
+func (g *_Gen) push_unknown(ident string) {
+	for _, u := range g.unknowns {
+		if u == ident {
+			return
+		}
+	}
+	g.unknowns = append(g.unknowns, ident)
+}
+
+// Returns Jule type of base type without indirections.
+// Returns reason as second result if type is not supported.
+func (g *_Gen) base_kind(t *_Type) (string, string) {
+	switch {
+	case t.reason != "":
+		return "", t.reason
+
+	case t.prim != "":
+		if t.ptr > 0 && !t.exact {
+			return "", "pointers of C's " + t.prim + " equivalent are not compatible with " + t.prim
+		}
+		if t.prim == CHAR_TYPE {
+			g.char_used = true
+		}
+		return t.prim, ""
+
+	case t.tag == "enum":
+		e := g.h.get_enum_by_type(t)
+		if e.ident() == "" {
+			return "i32", ""
+		}
+		return "cpp." + e.ident(), ""
+
+	case t.tag != "":
+		s := g.h.get_struct_by_type(t)
+		if s.ident() == "" {
+			return "", "anonymous " + t.tag + " types are not supported"
+		}
+		return "cpp." + s.ident(), ""
+	}
+
+	td := g.h.find_typedef(t.ident)
+	if td != nil {
+		// Typedef is not declared if not supported.
+		_, reason := g.kind(td.kind)
+		if reason != "" {
+			return "", t.ident + ": " + reason
+		}
+		return "cpp." + t.ident, ""
+	}
+
+	if g.h.find_struct_typedef(t.ident) != nil || g.h.find_enum_typedef(t.ident) != nil {
+		return "cpp." + t.ident, ""
+	}
+
+	kind, ok := STD_TYPEDEFS[t.ident]
+	if ok {
+		if t.ptr > 0 && !PTR_STD_TYPEDEFS[t.ident] {
+			return "", "pointers of " + t.ident + " are not compatible with " + kind
+		}
+		return kind, ""
+	}
+
+	g.push_unknown(t.ident)
+	return "cpp." + t.ident, ""
+}
+
+// Returns Jule type of C type.
+// Returns reason as second result if type is not supported.
+func (g *_Gen) kind(t *_Type) (string, string) {
+	if t.fnptr {
+		return "", "function pointers are not supported"
+	}
+
+	kind, reason := g.base_kind(t)
+	if reason != "" {
+		return "", reason
+	}
+
+	switch {
+	case kind == "":
+		return "", "type is not supported"
+
+	case kind == "void" && t.ptr == 0:
+		if len(t.arrays) > 0 {
+			return "", "void arrays are not supported"
+		}
+		return kind, ""
+
+	case kind == "void" && t.ptr == 1:
+		kind = "*unsafe"
+
+	case kind == "void":
+		return "", "pointers of void pointers are not supported"
+
+	default:
+		kind = strings.Repeat("*", t.ptr) + kind
+	}
+
+	prefix := ""
+	for _, size := range t.arrays {
+		if size == "" {
+			return "", "arrays without size are not supported"
+		}
+
+		lit, ok := parse_int_lit(size)
+		if !ok && !g.is_define(size) {
+			return "", "array size is not integer constant: " + size
+		}
+		if ok {
+			size = lit
+		}
+		prefix += "[" + size + "]"
+	}
+
+	return prefix + kind, ""
+}
+
+// Reports whether identifier is defined integer constant.
+func (g *_Gen) is_define(ident string) bool {
+	for _, d := range g.h.defines {
+		if d.ident == ident {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns skip comment of declaration.
+func gen_skip(ident string, reason string) string {
+	return "// skipped: " + ident + ": " + reason + "\n"
+}
+
+func (g *_Gen) gen_defines() string {
+	obj := ""
+	for _, d := range g.h.defines {
+		if lex.Is_keyword(d.ident) {
+			obj += gen_skip(d.ident, "identifier is keyword of Jule")
+			continue
+		}
+		obj += "const " + d.ident + " = " + d.value + "\n"
+	}
+	return obj
+}
+
+func (g *_Gen) gen_typedefs() string {
+	obj := ""
+	for _, t := range g.h.typedefs {
+		if lex.Is_keyword(t.ident) {
+			obj += gen_skip(t.ident, "identifier is keyword of Jule")
+			continue
+		}
+
+		kind, reason := g.kind(t.kind)
+		if reason == "" && kind == "void" {
+			reason = "void typedefs are not supported"
+		}
+		if reason != "" {
+			obj += gen_skip(t.ident, reason)
+			continue
+		}
+		obj += "cpp type " + t.ident + ": " + kind + "\n"
+	}
+	return obj
+}
+
+func (g *_Gen) gen_enums() string {
+	obj := ""
+	for _, e := range g.h.enums {
+		ident := e.ident()
+		kind := "i32"
+		if ident != "" {
+			if lex.Is_keyword(ident) {
+				obj += gen_skip(ident, "identifier is keyword of Jule")
+				continue
+			}
+			obj += "cpp type " + ident + ": i32\n"
+			kind = "cpp." + ident
+		}
+
+		for _, item := range e.items {
+			if lex.Is_keyword(item) {
+				obj += gen_skip(item, "identifier is keyword of Jule")
+				continue
+			}
+			obj += "cpp let " + item + ": " + kind + "\n"
+		}
+		obj += "\n"
+	}
+	return obj
+}
+
+func (g *_Gen) gen_struct(s *_Struct) string {
+	ident := s.ident()
+	switch {
+	case ident == "":
+		return gen_skip("<anonymous>", "anonymous structures are not supported")
+
+	case lex.Is_keyword(ident):
+		return gen_skip(ident, "identifier is keyword of Jule")
+	}
+
+	obj := ""
+	if s.typedef != "" {
+		obj += "//jule:typedef\n"
+	}
+
+	if !s.defined || len(s.fields) == 0 && len(s.skipped) == 0 {
+		return obj + "cpp struct " + ident + "{}\n"
+	}
+
+	obj += "cpp struct " + ident + " {\n"
+	for _, skip := range s.skipped {
+		obj += "\t" + gen_skip("field", skip)
+	}
+	for _, f := range s.fields {
+		if lex.Is_keyword(f.ident) {
+			obj += "\t" + gen_skip(f.ident, "identifier is keyword of Jule")
+			continue
+		}
+
+		kind, reason := g.kind(f.kind)
+		if reason == "" && kind == "void" {
+			reason = "void fields are not supported"
+		}
+		if reason != "" {
+			obj += "\t" + gen_skip(f.ident, reason)
+			continue
+		}
+		obj += "\tpub " + f.ident + ": " + kind + "\n"
+	}
+	obj += "}\n"
+	return obj
+}
+
+func (g *_Gen) gen_structs() string {
+	obj := ""
+	for _, s := range g.h.structs {
+		obj += g.gen_struct(s) + "\n"
+	}
+	return obj
+}
+
+func (g *_Gen) gen_vars() string {
+	obj := ""
+	for _, v := range g.h.vars {
+		if lex.Is_keyword(v.ident) {
+			obj += gen_skip(v.ident, "identifier is keyword of Jule")
+			continue
+		}
+
+		kind, reason := g.kind(v.kind)
+		if reason == "" && kind == "void" {
+			reason = "void variables are not supported"
+		}
+		if reason != "" {
+			obj += gen_skip(v.ident, reason)
+			continue
+		}
+		obj += "cpp let " + v.ident + ": " + kind + "\n"
+	}
+	return obj
+}
+
+// Returns parameter identifier for Jule.
+// Parameter identifiers are not part of linkage, so renamed if necessary.
+func get_param_ident(ident string, i int) string {
+	switch {
+	case ident == "":
+		return "p" + strconv.Itoa(i)
+
+	case lex.Is_keyword(ident) || ident == lex.KND_SELF:
+		return ident + "_"
+
+	default:
+		return ident
+	}
+}
+
+func (g *_Gen) gen_fn(f *_Fn) string {
+	switch {
+	case lex.Is_keyword(f.ident):
+		return gen_skip(f.ident, "identifier is keyword of Jule")
+
+	case f.variadic:
+		return gen_skip(f.ident, "variadic functions are not supported")
+	}
+
+	unsafety := false
+	params := ""
+	for i, p := range f.params {
+		kind, reason := g.kind(p.kind)
+		if reason == "" && kind == "void" {
+			reason = "void parameters are not supported"
+		}
+		if reason != "" {
+			return gen_skip(f.ident, reason)
+		}
+
+		unsafety = unsafety || p.kind.ptr > 0
+		if i > 0 {
+			params += ", "
+		}
+		params += get_param_ident(p.ident, i) + ": " + kind
+	}
+
+	result, reason := g.kind(f.result)
+	if reason != "" {
+		return gen_skip(f.ident, reason)
+	}
+	unsafety = unsafety || f.result.ptr > 0
+
+	obj := "cpp "
+	if unsafety {
+		obj += "unsafe "
+	}
+	obj += "fn " + f.ident + "(" + params + ")"
+	if result != "void" {
+		obj += ": " + result
+	}
+	return obj + "\n"
+}
+
+func (g *_Gen) gen_fns() string {
+	obj := ""
+	for _, f := range g.h.fns {
+		obj += g.gen_fn(f)
+	}
+	return obj
+}
+
+func (g *_Gen) gen_skipped() string {
+	obj := ""
+	for _, s := range g.h.skipped {
+		obj += gen_skip(s.ident, s.reason)
+	}
+	return obj
+}
+
+// Returns section with title if content is not empty.
+func gen_section(title string, content string) string {
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return ""
+	}
+	return "\n// " + title + "\n\n" + content + "\n"
+}
+
+// Generates Jule declarations of C header.
+// Include is the path of header for use declaration,
+// relative to directory of generated file.
+func Gen(include string, src string) string {
+	g := &_Gen{h: parse_header(src)}
+
+	// Declarations generated first to collect
+	// referenced but not declared types.
+	defines := g.gen_defines()
+	typedefs := g.gen_typedefs()
+	enums := g.gen_enums()
+	structs := g.gen_structs()
+	vars := g.gen_vars()
+	fns := g.gen_fns()
+	skipped := g.gen_skipped()
+
+	externs := ""
+	if g.char_used {
+		externs += "cpp type char: i8\n"
+	}
+	for _, u := range g.unknowns {
+		externs += "//jule:typedef\ncpp struct " + u + "{}\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Auto generated by JuleC bindgen.\n")
+	sb.WriteString("// Source: " + include + "\n\n")
+	sb.WriteString("use cpp \"" + include + "\"\n")
+	sb.WriteString(gen_section("External types.", externs))
+	sb.WriteString(gen_section("Constants.", defines))
+	sb.WriteString(gen_section("Types.", typedefs))
+	sb.WriteString(gen_section("Enumerations.", enums))
+	sb.WriteString(gen_section("Structures.", structs))
+	sb.WriteString(gen_section("Variables.", vars))
+	sb.WriteString(gen_section("Functions.", fns))
+	sb.WriteString(gen_section("Skipped declarations.", skipped))
+	return sb.String()
+}
@@ -0,0 +1,225 @@
+package bindgen
+
+import (
+	"strings"
+)
+
+// Token kinds of C tokens.
+const (
+	_TOKEN_IDENT = iota
+	_TOKEN_NUM
+	_TOKEN_STR
+	_TOKEN_PUNCT
+)
+
+// C token.
+type _Token struct {
+	kind uint8
+	text string
+}
+
+// Macro definition of integer constant.
+type _Define struct {
+	ident string
+	value string
+}
+
+// Removes line continuations and comments of C source.
+func clean_source(src string) string {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\\\n", "")
+
+	var sb strings.Builder
+	for i := 0; i < len(src); i++ {
+		b := src[i]
+		switch {
+		case b == '"' || b == '\'':
+			// Keep literals as is.
+			j := i + 1
+			for j < len(src) && src[j] != b && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			sb.WriteString(src[i : j+1])
+			i = j
+
+		case b == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			sb.WriteByte('\n')
+
+		case b == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end == -1 {
+				return sb.String()
+			}
+			// Keep lines of comment for preprocessor lines.
+			sb.WriteString(strings.Repeat("\n", strings.Count(src[i:i+2+end], "\n")))
+			sb.WriteByte(' ')
+			i += end + 3
+
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	return sb.String()
+}
+
+// Reports whether byte is allowed in C identifiers.
+func is_ident_byte(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// Returns integer literal of macro value without suffixes.
+// Returns empty string if value is not integer literal.
+func get_define_value(value string) string {
+	value = strings.TrimSpace(value)
+	for len(value) > 1 && value[0] == '(' && value[len(value)-1] == ')' {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+
+	sign := ""
+	if value != "" && (value[0] == '-' || value[0] == '+') {
+		if value[0] == '-' {
+			sign = "-"
+		}
+		value = strings.TrimSpace(value[1:])
+	}
+
+	value = strings.TrimRight(value, "uUlL")
+	if value == "" {
+		return ""
+	}
+
+	lit, ok := parse_int_lit(value)
+	if !ok {
+		return ""
+	}
+	return sign + lit
+}
+
+// Parses preprocessor line.
+// Returns nil if line is not object-like macro with value.
+// Value of definition is empty if macro is not integer constant.
+func parse_define(line string) *_Define {
+	line = strings.TrimSpace(line[1:]) // Remove hash.
+	if !strings.HasPrefix(line, "define") {
+		return nil
+	}
+	line = line[len("define"):]
+	if line == "" || (line[0] != ' ' && line[0] != '\t') {
+		return nil
+	}
+	line = strings.TrimSpace(line)
+
+	i := 0
+	for i < len(line) && is_ident_byte(line[i]) {
+		i++
+	}
+	if i == 0 || i < len(line) && line[i] == '(' {
+		// Function-like macros are not supported.
+		return nil
+	}
+
+	if strings.TrimSpace(line[i:]) == "" {
+		// Macros without value, such as include guards.
+		return nil
+	}
+
+	return &_Define{
+		ident: line[:i],
+		value: get_define_value(line[i:]),
+	}
+}
+
+// Punctuations of C that have more than one byte.
+var _LONG_PUNCTS = [...]string{"...", "::", "->", "<<", ">>"}
+
+// Tokenizes C source without preprocessor lines.
+// Returns tokens, integer constant definitions and skipped macros.
+func tokenize(src string) ([]_Token, []*_Define, []_Skip) {
+	var tokens []_Token
+	var defines []*_Define
+	var skipped []_Skip
+
+	for _, line := range strings.Split(clean_source(src), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			d := parse_define(trimmed)
+			switch {
+			case d == nil:
+				// Not object-like macro.
+
+			case d.value == "":
+				skipped = append(skipped, _Skip{d.ident, "macro value is not integer literal"})
+
+			default:
+				defines = append(defines, d)
+			}
+			continue
+		}
+
+		tokens = append(tokens, tokenize_line(line)...)
+	}
+
+	return tokens, defines, skipped
+}
+
+func tokenize_line(line string) []_Token {
+	var tokens []_Token
+	for i := 0; i < len(line); {
+		b := line[i]
+		switch {
+		case b == ' ' || b == '\t' || b == '\f' || b == '\v':
+			i++
+
+		case b == '"' || b == '\'':
+			j := i + 1
+			for j < len(line) && line[j] != b {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(line) {
+				j = len(line) - 1
+			}
+			tokens = append(tokens, _Token{kind: _TOKEN_STR, text: line[i : j+1]})
+			i = j + 1
+
+		case '0' <= b && b <= '9':
+			j := i
+			for j < len(line) && (is_ident_byte(line[j]) || line[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, _Token{kind: _TOKEN_NUM, text: line[i:j]})
+			i = j
+
+		case is_ident_byte(b):
+			j := i
+			for j < len(line) && is_ident_byte(line[j]) {
+				j++
+			}
+			tokens = append(tokens, _Token{kind: _TOKEN_IDENT, text: line[i:j]})
+			i = j
+
+		default:
+			punct := line[i : i+1]
+			for _, p := range _LONG_PUNCTS {
+				if strings.HasPrefix(line[i:], p) {
+					punct = p
+					break
+				}
+			}
+			tokens = append(tokens, _Token{kind: _TOKEN_PUNCT, text: punct})
+			i += len(punct)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,773 @@
+package bindgen
+
+import (
+	"strconv"
+	"strings"
+)
+
+// C type.
+type _Type struct {
+	prim   string   // Jule type of primitive type, empty if not primitive.
+	exact  bool     // Primitive type is same type with Jule's type in C++.
+	tag    string   // One of struct, union and enum; empty if not tagged.
+	ident  string   // Identifier of tagged type or typedef.
+	ptr    int      // Count of pointer indirection.
+	arrays []string // Array sizes by dimension order.
+	fnptr  bool     // Function pointer.
+	reason string   // Reason of unsupported primitive type, empty if supported.
+}
+
+// Variable, parameter or field.
+type _Var struct {
+	ident string
+	kind  *_Type
+}
+
+// Function prototype.
+type _Fn struct {
+	ident    string
+	result   *_Type
+	params   []*_Var
+	variadic bool
+}
+
+// Structure or union.
+type _Struct struct {
+	tag     string
+	typedef string
+	fields  []*_Var
+	defined bool
+	skipped []string // Skipped fields.
+}
+
+// Returns identifier of structure for Jule declarations.
+func (s *_Struct) ident() string {
+	if s.typedef != "" {
+		return s.typedef
+	}
+	return s.tag
+}
+
+// Enumeration.
+type _Enum struct {
+	tag     string
+	typedef string
+	items   []string
+}
+
+// Returns identifier of enumeration for Jule declarations.
+// Returns empty string if enumeration is anonymous.
+func (e *_Enum) ident() string {
+	if e.typedef != "" {
+		return e.typedef
+	}
+	return e.tag
+}
+
+// Skipped declaration.
+type _Skip struct {
+	ident  string
+	reason string
+}
+
+// Declarations of C header.
+type _Header struct {
+	defines  []*_Define
+	structs  []*_Struct
+	enums    []*_Enum
+	typedefs []*_Var
+	vars     []*_Var
+	fns      []*_Fn
+	skipped  []_Skip
+}
+
+// Returns structure by tag, registers new one if not exist.
+func (h *_Header) get_struct(tag string) *_Struct {
+	if tag != "" {
+		for _, s := range h.structs {
+			if s.tag == tag {
+				return s
+			}
+		}
+	}
+
+	s := &_Struct{tag: tag}
+	h.structs = append(h.structs, s)
+	return s
+}
+
+// Returns enumeration by tag, registers new one if not exist.
+func (h *_Header) get_enum(tag string) *_Enum {
+	if tag != "" {
+		for _, e := range h.enums {
+			if e.tag == tag {
+				return e
+			}
+		}
+	}
+
+	e := &_Enum{tag: tag}
+	h.enums = append(h.enums, e)
+	return e
+}
+
+// Returns structure by typedef identifier.
+// Returns nil if not exist.
+func (h *_Header) find_struct_typedef(ident string) *_Struct {
+	for _, s := range h.structs {
+		if s.typedef == ident {
+			return s
+		}
+	}
+	return nil
+}
+
+// Returns enumeration by typedef identifier.
+// Returns nil if not exist.
+func (h *_Header) find_enum_typedef(ident string) *_Enum {
+	for _, e := range h.enums {
+		if e.typedef == ident {
+			return e
+		}
+	}
+	return nil
+}
+
+// Returns typedef by identifier.
+// Returns nil if not exist.
+func (h *_Header) find_typedef(ident string) *_Var {
+	for _, t := range h.typedefs {
+		if t.ident == ident {
+			return t
+		}
+	}
+	return nil
+}
+
+// Returns decimal form of C integer literal.
+func parse_int_lit(lit string) (string, bool) {
+	n, err := strconv.ParseUint(lit, 0, 64)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatUint(n, 10), true
+}
+
+// Qualifiers and storage classes that not affects types.
+var _IGNORED_SPECS = map[string]bool{
+	"const":         true,
+	"volatile":      true,
+	"extern":        true,
+	"static":        true,
+	"inline":        true,
+	"__inline":      true,
+	"__inline__":    true,
+	"register":      true,
+	"restrict":      true,
+	"__restrict":    true,
+	"__restrict__":  true,
+	"__extension__": true,
+	"_Noreturn":     true,
+	"auto":          true,
+}
+
+// Specifiers that have parenthesized arguments to skip.
+var _SKIPPED_SPECS = map[string]bool{
+	"__attribute__": true,
+	"__declspec":    true,
+	"__asm__":       true,
+	"__asm":         true,
+	"asm":           true,
+}
+
+// Primitive type specifiers.
+var _PRIM_SPECS = map[string]bool{
+	"signed":   true,
+	"unsigned": true,
+	"char":     true,
+	"short":    true,
+	"int":      true,
+	"long":     true,
+	"float":    true,
+	"double":   true,
+	"void":     true,
+	"_Bool":    true,
+	"bool":     true,
+}
+
+// Returns Jule type of primitive type specifiers.
+// Based on LP64 data model.
+// Returns empty string if not supported, such as long double.
+// Second result reports whether types are same type in C++.
+// For example, C's int and Jule's i32 have same size but different types in C++,
+// so pointers of them are not compatible.
+func get_prim(specs []string) (string, bool) {
+	signed, unsigned := false, false
+	longs := 0
+	kind := ""
+	for _, spec := range specs {
+		switch spec {
+		case "signed":
+			signed = true
+
+		case "unsigned":
+			unsigned = true
+
+		case "long":
+			longs++
+
+		case "int":
+			if kind == "" {
+				kind = spec
+			}
+
+		default:
+			kind = spec
+		}
+	}
+
+	switch kind {
+	case "void":
+		return "void", true
+
+	case "_Bool", "bool":
+		return "bool", true
+
+	case "float":
+		return "f32", true
+
+	case "double":
+		if longs > 0 {
+			return "", false // Long double is not supported.
+		}
+		return "f64", true
+
+	case "char":
+		switch {
+		case unsigned:
+			return "u8", true
+
+		case signed:
+			return "i8", true
+
+		default:
+			return CHAR_TYPE, true
+		}
+
+	case "short":
+		if unsigned {
+			return "u16", true
+		}
+		return "i16", true
+	}
+
+	// Only long long is same type with Jule's 64-bit integers.
+	if longs > 0 {
+		if unsigned {
+			return "u64", longs > 1
+		}
+		return "i64", longs > 1
+	}
+
+	if unsigned {
+		return "u32", false
+	}
+	return "i32", false
+}
+
+// C header parser.
+type _Parser struct {
+	h    *_Header
+	toks []_Token
+}
+
+// Skips balanced brackets starting at i.
+// Returns index after closing bracket.
+func skip_brackets(toks []_Token, i int) int {
+	depth := 0
+	for ; i < len(toks); i++ {
+		switch toks[i].text {
+		case "(", "[", "{":
+			depth++
+
+		case ")", "]", "}":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// Skips ignored specifiers starting at i.
+// Returns index of first not ignored token.
+func skip_ignored(toks []_Token, i int) int {
+	for i < len(toks) {
+		t := toks[i]
+		switch {
+		case t.kind != _TOKEN_IDENT:
+			return i
+
+		case _IGNORED_SPECS[t.text]:
+			i++
+
+		case _SKIPPED_SPECS[t.text]:
+			i++
+			if i < len(toks) && toks[i].text == "(" {
+				i = skip_brackets(toks, i)
+			}
+
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// Parses type specifiers.
+// Returns nil if there is no type specifier.
+func (p *_Parser) parse_specs(toks []_Token, i *int) *_Type {
+	var prims []string
+	var t *_Type = nil
+
+loop:
+	for {
+		*i = skip_ignored(toks, *i)
+		if *i >= len(toks) || toks[*i].kind != _TOKEN_IDENT {
+			break
+		}
+
+		tok := toks[*i]
+		switch {
+		case _PRIM_SPECS[tok.text]:
+			if t != nil {
+				break loop
+			}
+			prims = append(prims, tok.text)
+			*i++
+
+		case tok.text == "struct" || tok.text == "union" || tok.text == "enum":
+			if t != nil || len(prims) > 0 {
+				break loop
+			}
+			t = p.parse_tagged(toks, i)
+
+		default:
+			if t != nil || len(prims) > 0 {
+				break loop
+			}
+			t = &_Type{ident: tok.text}
+			*i++
+		}
+	}
+
+	if len(prims) > 0 {
+		t = &_Type{}
+		t.prim, t.exact = get_prim(prims)
+		if t.prim == "" {
+			t.reason = strings.Join(prims, " ") + " is not supported"
+		}
+	}
+	return t
+}
+
+// Parses tagged type specifier with optional body.
+func (p *_Parser) parse_tagged(toks []_Token, i *int) *_Type {
+	t := &_Type{tag: toks[*i].text}
+	*i++
+	*i = skip_ignored(toks, *i)
+	if *i < len(toks) && toks[*i].kind == _TOKEN_IDENT {
+		t.ident = toks[*i].text
+		*i++
+	}
+
+	has_body := *i < len(toks) && toks[*i].text == "{"
+	if t.tag == "enum" {
+		e := p.h.get_enum(t.ident)
+		if has_body {
+			end := skip_brackets(toks, *i)
+			e.items = parse_enum_items(toks[*i+1 : end-1])
+			*i = end
+		}
+		if t.ident == "" {
+			// Keep anonymous enumeration for typedef.
+			t.ident = "\x00" + strconv.Itoa(len(p.h.enums)-1)
+		}
+		return t
+	}
+
+	s := p.h.get_struct(t.ident)
+	if has_body {
+		end := skip_brackets(toks, *i)
+		p.parse_fields(s, toks[*i+1:end-1])
+		s.defined = true
+		*i = end
+	}
+	if t.ident == "" {
+		// Keep anonymous structure for typedef.
+		t.ident = "\x00" + strconv.Itoa(len(p.h.structs)-1)
+	}
+	return t
+}
+
+// Returns identifiers of enumeration items.
+func parse_enum_items(toks []_Token) []string {
+	var items []string
+	expect_ident := true
+	depth := 0
+	for _, t := range toks {
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+
+		case ")", "]", "}":
+			depth--
+
+		case ",":
+			if depth == 0 {
+				expect_ident = true
+			}
+
+		default:
+			if expect_ident && t.kind == _TOKEN_IDENT {
+				items = append(items, t.text)
+				expect_ident = false
+			}
+		}
+	}
+	return items
+}
+
+// Splits tokens by separator at zero depth.
+func split_tokens(toks []_Token, sep string) [][]_Token {
+	var parts [][]_Token
+	depth := 0
+	start := 0
+	for i, t := range toks {
+		switch t.text {
+		case "(", "[", "{":
+			depth++
+
+		case ")", "]", "}":
+			depth--
+
+		case sep:
+			if depth == 0 {
+				parts = append(parts, toks[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(toks) {
+		parts = append(parts, toks[start:])
+	}
+	return parts
+}
+
+// Parses fields of structure body.
+func (p *_Parser) parse_fields(s *_Struct, toks []_Token) {
+	for _, decl := range split_tokens(toks, ";") {
+		i := 0
+		base := p.parse_specs(decl, &i)
+		if base == nil {
+			continue
+		}
+
+		if base.tag != "" && strings.HasPrefix(base.ident, "\x00") {
+			s.skipped = append(s.skipped, "anonymous nested "+base.tag)
+			continue
+		}
+
+		for i < len(decl) {
+			d := p.parse_declarator(decl, &i, base)
+			if d == nil {
+				break
+			}
+
+			// Skip bit-field width.
+			if i < len(decl) && decl[i].text == ":" {
+				i += 2
+			}
+
+			s.fields = append(s.fields, &_Var{ident: d.ident, kind: d.kind})
+
+			if i < len(decl) && decl[i].text == "," {
+				i++
+				continue
+			}
+			break
+		}
+	}
+}
+
+// Declarator of declaration.
+type _Declarator struct {
+	ident    string
+	kind     *_Type
+	fn       bool
+	params   []*_Var
+	variadic bool
+}
+
+// Parses declarator for base type.
+// Returns nil if declarator is invalid.
+func (p *_Parser) parse_declarator(toks []_Token, i *int, base *_Type) *_Declarator {
+	t := *base
+	t.arrays = nil
+	d := &_Declarator{kind: &t}
+
+	for {
+		*i = skip_ignored(toks, *i)
+		if *i < len(toks) && toks[*i].text == "*" {
+			t.ptr++
+			*i++
+			continue
+		}
+		break
+	}
+
+	if *i+1 < len(toks) && toks[*i].text == "(" && toks[*i+1].text == "*" {
+		// Function pointer.
+		t.fnptr = true
+		*i += 2
+		*i = skip_ignored(toks, *i)
+		if *i < len(toks) && toks[*i].kind == _TOKEN_IDENT {
+			d.ident = toks[*i].text
+			*i++
+		}
+		for *i < len(toks) && toks[*i].text != ")" {
+			*i++
+		}
+		*i++
+		if *i < len(toks) && toks[*i].text == "(" {
+			*i = skip_brackets(toks, *i)
+		}
+		return d
+	}
+
+	if *i < len(toks) && toks[*i].kind == _TOKEN_IDENT {
+		d.ident = toks[*i].text
+		*i++
+	}
+
+	for *i < len(toks) {
+		*i = skip_ignored(toks, *i)
+		if *i >= len(toks) {
+			break
+		}
+
+		switch toks[*i].text {
+		case "[":
+			end := skip_brackets(toks, *i)
+			size := ""
+			for _, st := range toks[*i+1 : end-1] {
+				size += st.text
+			}
+			t.arrays = append(t.arrays, size)
+			*i = end
+
+		case "(":
+			end := skip_brackets(toks, *i)
+			d.fn = true
+			d.params, d.variadic = p.parse_params(toks[*i+1 : end-1])
+			*i = end
+
+		default:
+			return d
+		}
+	}
+	return d
+}
+
+// Parses parameters of function declarator.
+func (p *_Parser) parse_params(toks []_Token) (params []*_Var, variadic bool) {
+	if len(toks) == 1 && toks[0].text == "void" {
+		return nil, false
+	}
+
+	for _, part := range split_tokens(toks, ",") {
+		if len(part) == 1 && part[0].text == "..." {
+			variadic = true
+			continue
+		}
+
+		i := 0
+		base := p.parse_specs(part, &i)
+		if base == nil {
+			continue
+		}
+
+		d := p.parse_declarator(part, &i, base)
+		if d == nil {
+			continue
+		}
+
+		// Array parameters are pointers.
+		if len(d.kind.arrays) > 0 {
+			d.kind.ptr++
+			d.kind.arrays = d.kind.arrays[1:]
+		}
+
+		params = append(params, &_Var{ident: d.ident, kind: d.kind})
+	}
+
+	return
+}
+
+// Parses top-level declaration.
+func (p *_Parser) parse_decl(decl []_Token) {
+	i := skip_ignored(decl, 0)
+	is_typedef := false
+	if i < len(decl) && decl[i].text == "typedef" {
+		is_typedef = true
+		i++
+	}
+
+	base := p.parse_specs(decl, &i)
+	if base == nil {
+		return
+	}
+
+	for i < len(decl) {
+		d := p.parse_declarator(decl, &i, base)
+		if d == nil || d.ident == "" {
+			return
+		}
+
+		switch {
+		case is_typedef:
+			p.push_typedef(d)
+
+		case d.fn:
+			p.h.fns = append(p.h.fns, &_Fn{
+				ident:    d.ident,
+				result:   d.kind,
+				params:   d.params,
+				variadic: d.variadic,
+			})
+
+		default:
+			p.h.vars = append(p.h.vars, &_Var{ident: d.ident, kind: d.kind})
+		}
+
+		i = skip_ignored(decl, i)
+		if i < len(decl) && decl[i].text == "," {
+			i++
+			continue
+		}
+		return
+	}
+}
+
+func (p *_Parser) push_typedef(d *_Declarator) {
+	t := d.kind
+	plain := t.ptr == 0 && len(t.arrays) == 0 && !t.fnptr && !d.fn
+
+	switch {
+	case d.fn:
+		p.h.skipped = append(p.h.skipped, _Skip{d.ident, "function typedefs are not supported"})
+		return
+
+	case plain && t.tag == "enum":
+		e := p.h.get_enum_by_type(t)
+		if e.typedef == "" {
+			e.typedef = d.ident
+			return
+		}
+
+	case plain && t.tag != "":
+		s := p.h.get_struct_by_type(t)
+		if s.typedef == "" {
+			s.typedef = d.ident
+			return
+		}
+	}
+
+	p.h.typedefs = append(p.h.typedefs, &_Var{ident: d.ident, kind: t})
+}
+
+// Returns enumeration of tagged type.
+func (h *_Header) get_enum_by_type(t *_Type) *_Enum {
+	if strings.HasPrefix(t.ident, "\x00") {
+		i, _ := strconv.Atoi(t.ident[1:])
+		return h.enums[i]
+	}
+	return h.get_enum(t.ident)
+}
+
+// Returns structure of tagged type.
+func (h *_Header) get_struct_by_type(t *_Type) *_Struct {
+	if strings.HasPrefix(t.ident, "\x00") {
+		i, _ := strconv.Atoi(t.ident[1:])
+		return h.structs[i]
+	}
+	return h.get_struct(t.ident)
+}
+
+// Parses all top-level declarations.
+func (p *_Parser) parse() {
+	toks := p.toks
+	for i := 0; i < len(toks); {
+		t := toks[i]
+		switch {
+		case t.text == ";" || t.text == "}" || t.text == "{":
+			i++
+			continue
+
+		case t.text == "extern" && i+1 < len(toks) && toks[i+1].kind == _TOKEN_STR:
+			// Linkage specification such as extern "C".
+			i += 2
+			continue
+		}
+
+		start := i
+		depth := 0
+		var decl []_Token
+	collect:
+		for ; i < len(toks); i++ {
+			switch toks[i].text {
+			case "{":
+				if depth == 0 && i > start && toks[i-1].text == ")" {
+					// Function definition, skip body.
+					decl = toks[start:i]
+					i = skip_brackets(toks, i)
+					break collect
+				}
+				depth++
+
+			case "(", "[":
+				depth++
+
+			case ")", "]", "}":
+				depth--
+
+			case ";":
+				if depth == 0 {
+					decl = toks[start:i]
+					i++
+					break collect
+				}
+			}
+		}
+
+		if decl == nil {
+			decl = toks[start:]
+		}
+		p.parse_decl(decl)
+	}
+}
+
+// Parses C header source.
+func parse_header(src string) *_Header {
+	toks, defines, skipped := tokenize(src)
+	p := &_Parser{
+		h: &_Header{
+			defines: defines,
+			skipped: skipped,
+		},
+		toks: toks,
+	}
+	p.parse()
+	return p.h
+}
@@ -10,10 +10,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/julelang/jule"
 	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/cmd/julec/bindgen"
 	"github.com/julelang/jule/cmd/julec/obj/cxx"
 	"github.com/julelang/jule/lex"
 )
@@ -21,11 +23,17 @@ import (
 const CMD_HELP = "help"
 const CMD_VERSION = "version"
 const CMD_TOOL = "tool"
+const CMD_VET = "vet"
+const CMD_EXPLAIN = "explain"
+const CMD_FIX = "fix"
 
 var HELP_MAP = [...][2]string{
 	{CMD_HELP, "Show help"},
 	{CMD_VERSION, "Show version"},
 	{CMD_TOOL, "Tools for effective Jule"},
+	{CMD_VET, "Report suspicious constructs"},
+	{CMD_EXPLAIN, "Explain error or warning by code"},
+	{CMD_FIX, "Apply suggested fixes of diagnostics"},
 }
 
 func help() {
@@ -66,18 +74,76 @@ func list_horizontal_slice(s []string) string {
 	return lst[1 : len(lst)-1]
 }
 
+// Returns path of header relative to directory of output file.
+// Paths of cpp use declarations are relative to the source file.
+func get_bindgen_include(header string, out string) (string, error) {
+	header, err := filepath.Abs(header)
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.Abs(filepath.Dir(out))
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(dir, header)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// Generates cpp-linked Jule declarations of C header.
+// Writes declarations to Jule source file in working directory
+// with same name as header.
+func bindgen_tool() {
+	if len(os.Args) < 4 {
+		print_error_message("missing header path for bindgen")
+		return
+	} else if len(os.Args) > 4 {
+		print_error_message("invalid command: " + os.Args[4])
+		return
+	}
+
+	path := os.Args[3]
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		print_error_message(err.Error())
+		return
+	}
+
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name)) + build.EXT
+	include, err := get_bindgen_include(path, name)
+	if err != nil {
+		print_error_message(err.Error())
+		return
+	}
+	err = os.WriteFile(name, []byte(bindgen.Gen(include, string(bytes))), 0o666)
+	if err != nil {
+		print_error_message(err.Error())
+		return
+	}
+	println("generated:", name)
+}
+
 func tool() {
 	if len(os.Args) == 2 {
 		println(`tool commands:
  distos     Lists all supported operating systems
- distarch   Lists all supported architects`)
+ distarch   Lists all supported architects
+ bindgen    Generates cpp-linked declarations from C header`)
+		return
+	}
+
+	cmd := os.Args[2]
+	if cmd == "bindgen" {
+		bindgen_tool()
 		return
 	} else if len(os.Args) > 3 {
 		print_error_message("invalid command: " + os.Args[3])
 		return
 	}
 
-	cmd := os.Args[2]
 	switch cmd {
 	case "distos":
 		print("supported operating systems:\n ")
@@ -92,6 +158,52 @@ func tool() {
 	}
 }
 
+// Analyzes package with all vet checks without compilation.
+// Checks are switchable by warning options such as "-Wno-shadow".
+func vet() {
+	for _, class := range build.VET_CHECKS {
+		build.WARN_LEVELS[class] = build.WARN_LEVEL_WARN
+	}
+
+	// Skip command argument.
+	path := parse_options(os.Args[1:])
+	if path == "" {
+		exit_err(build.Errorf("missing_compile_path"))
+	}
+
+	cxx.Vet(path)
+}
+
+// Applies machine-applicable fixes of diagnostics to source files of package.
+// Package is re-checked after fixes, fixes are reverted if cause new errors.
+func fix() {
+	// Skip command argument.
+	path := parse_options(os.Args[1:])
+	if path == "" {
+		exit_err(build.Errorf("missing_compile_path"))
+	}
+
+	cxx.Fix(path)
+}
+
+// Prints explanation of error or warning by public code such as "J0017".
+func explain() {
+	if len(os.Args) < 3 {
+		print_error_message("missing code for explain")
+		return
+	} else if len(os.Args) > 3 {
+		print_error_message("invalid command: " + os.Args[3])
+		return
+	}
+
+	text, ok := build.Explain(os.Args[2])
+	if !ok {
+		print_error_message("unknown code: " + os.Args[2])
+		return
+	}
+	println(text)
+}
+
 func process_command() bool {
 	switch os.Args[1] {
 	case CMD_HELP:
@@ -103,6 +215,15 @@ func process_command() bool {
 	case CMD_TOOL:
 		tool()
 
+	case CMD_VET:
+		vet()
+
+	case CMD_EXPLAIN:
+		explain()
+
+	case CMD_FIX:
+		fix()
+
 	default:
 		return false
 	}
@@ -185,6 +306,135 @@ func parse_out_option(args []string, i *int) {
 	cxx.OUT = value
 }
 
+func parse_emit_build_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	switch value {
+	case "":
+		exit_err("missing option value: --emit-build")
+
+	case cxx.EMIT_CMAKE, cxx.EMIT_MAKE, cxx.EMIT_COMPILE_COMMANDS:
+		cxx.EMIT_BUILD = value
+
+	default:
+		exit_err("invalid option value for --emit-build: " + value)
+	}
+}
+
+func parse_buildmode_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	switch value {
+	case "":
+		exit_err("missing option value: --buildmode")
+
+	case cxx.BUILDMODE_EXE, cxx.BUILDMODE_C_ARCHIVE,
+		cxx.BUILDMODE_C_SHARED, cxx.BUILDMODE_OBJECT:
+		cxx.BUILDMODE = value
+
+	default:
+		exit_err("invalid option value for --buildmode: " + value)
+	}
+}
+
+func parse_api_path_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	if value == "" {
+		exit_err("missing option value: --api-path")
+	}
+	cxx.API_PATH = value
+}
+
+func parse_pass_policy_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	switch value {
+	case "":
+		exit_err("missing option value: --pass-policy")
+
+	case cxx.PASS_POLICY_PERMISSIVE, cxx.PASS_POLICY_STRICT:
+		cxx.PASS_POLICY = value
+
+	default:
+		exit_err("invalid option value for --pass-policy: " + value)
+	}
+}
+
+func parse_pass_allow_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	if value == "" {
+		exit_err("missing option value: --pass-allow")
+	}
+
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != "" {
+			cxx.PASS_ALLOWLIST = append(cxx.PASS_ALLOWLIST, pattern)
+		}
+	}
+}
+
+func parse_trust_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	if value == "" {
+		exit_err("missing option value: --trust")
+	}
+
+	cxx.TRUSTED = nil
+	for _, level := range strings.Split(value, ",") {
+		level = strings.TrimSpace(level)
+		switch level {
+		case "none":
+
+		case cxx.TRUST_STD, cxx.TRUST_MAIN, cxx.TRUST_VENDOR:
+			cxx.TRUSTED = append(cxx.TRUSTED, level)
+
+		default:
+			exit_err("invalid option value for --trust: " + level)
+		}
+	}
+}
+
+func parse_link_manifest_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	if value == "" {
+		exit_err("missing option value: --link-manifest")
+	}
+	cxx.LINK_MANIFEST_PATH = value
+}
+
+func parse_diag_format_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	switch value {
+	case "":
+		exit_err("missing option value: --diag-format")
+
+	case build.DIAG_FORMAT_RICH, build.DIAG_FORMAT_SHORT, build.DIAG_FORMAT_JSON:
+		cxx.DIAG_FORMAT = value
+
+	default:
+		exit_err("invalid option value for --diag-format: " + value)
+	}
+}
+
+func parse_lang_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	switch {
+	case value == "":
+		exit_err("missing option value: --lang")
+
+	case !build.Is_lang(value):
+		exit_err("invalid option value for --lang: " + value +
+			"\nsupported languages: " + list_horizontal_slice(build.Langs()))
+	}
+	build.LANG = value
+}
+
+func parse_lang_file_option(args []string, i *int) {
+	value := get_option_value(args, i)
+	if value == "" {
+		exit_err("missing option value: --lang-file")
+	}
+	build.LANG_FILE = value
+}
+
 func parse_compiler_option(args []string, i *int) {
 	value := get_option_value(args, i)
 	switch value {
@@ -204,7 +454,44 @@ func parse_compiler_option(args []string, i *int) {
 	cxx.COMPILER = value
 }
 
+// Parses warning option in "-W<class>" or "-Wno-<class>" form.
+// Reports whether argument is warning option.
+func parse_warning_option(arg string) bool {
+	if !strings.HasPrefix(arg, "-W") {
+		return false
+	}
+
+	class := arg[len("-W"):]
+	level := build.WARN_LEVEL_WARN
+	if strings.HasPrefix(class, "no-") {
+		class = class[len("no-"):]
+		level = build.WARN_LEVEL_OFF
+	}
+
+	if !build.Is_warn_class(class) {
+		exit_err("undefined warning class: " + arg)
+	}
+	build.WARN_LEVELS[class] = level
+	return true
+}
+
+// Splits options in "--option=value" form into option and value arguments.
+// Short options are not splitted to keep values such as "--pass-allow -std=*".
+func split_option_values(args []string) []string {
+	var splitted []string
+	for _, arg := range args {
+		i := strings.IndexByte(arg, '=')
+		if i > 0 && strings.HasPrefix(arg, "--") {
+			splitted = append(splitted, arg[:i], arg[i+1:])
+		} else {
+			splitted = append(splitted, arg)
+		}
+	}
+	return splitted
+}
+
 func parse_options(args []string) string {
+	args = split_option_values(args)
 	cmd := ""
 	i := 1 // Start 1 because the index 0 is a path, not an command-line argument
 	for ; i < len(args); i++ {
@@ -225,14 +512,71 @@ func parse_options(args []string) string {
 		case "--compiler":
 			parse_compiler_option(args, &i)
 
+		case "--no-dce":
+			cxx.DCE = false
+
+		case "--dce-report":
+			cxx.DCE_REPORT = true
+
+		case "--amalgamate":
+			cxx.AMALGAMATE = true
+
+		case "--api-path":
+			parse_api_path_option(args, &i)
+
+		case "--emit-build":
+			parse_emit_build_option(args, &i)
+
+		case "--buildmode":
+			parse_buildmode_option(args, &i)
+
+		case "--pass-policy":
+			parse_pass_policy_option(args, &i)
+
+		case "--pass-allow":
+			parse_pass_allow_option(args, &i)
+
+		case "--trust":
+			parse_trust_option(args, &i)
+
+		case "--print-passes":
+			cxx.PRINT_PASSES = true
+
+		case "--link-manifest":
+			parse_link_manifest_option(args, &i)
+
+		case "-Werror":
+			cxx.WERROR = true
+
+		case "--diag-format":
+			parse_diag_format_option(args, &i)
+
+		case "--lang":
+			parse_lang_option(args, &i)
+
+		case "--lang-file":
+			parse_lang_file_option(args, &i)
+
 		default:
-			exit_err("undefined option: " + arg)
+			if !parse_warning_option(arg) {
+				exit_err("undefined option: " + arg)
+			}
 		}
 	}
 	cmd = strings.TrimSpace(cmd)
+	load_messages()
 	return cmd
 }
 
+// Loads messages of selected language.
+// Fails if catalogs has problems.
+func load_messages() {
+	problems := build.Load_messages()
+	if len(problems) > 0 {
+		exit_err(strings.Join(problems, "\n"))
+	}
+}
+
 func main() {
 	path := parse_options(os.Args)
 	if path == "" {
@@ -0,0 +1,150 @@
+package cxx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julelang/jule/build"
+)
+
+// Include directive prefix of C/C++.
+const INCLUDE_PREFIX = "#include"
+
+// Inline headers into output.
+// Sets by command-line inputs.
+var AMALGAMATE = false
+
+// Include path of API header.
+// Uses build.PATH_API if empty.
+// Sets by command-line inputs.
+var API_PATH = ""
+
+// Returns local include path of line.
+// Returns empty string if line is not local include directive.
+// System headers such as <stddef.h> are not local.
+func get_local_include(line string) string {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, INCLUDE_PREFIX) {
+		return ""
+	}
+
+	line = strings.TrimSpace(line[len(INCLUDE_PREFIX):])
+	if len(line) < 2 || line[0] != '"' {
+		return ""
+	}
+
+	end := strings.IndexByte(line[1:], '"')
+	if end == -1 {
+		return ""
+	}
+	return line[1 : end+1]
+}
+
+// Returns path relative to root directory.
+// Reports whether path is inside of root directory.
+func get_rel_path(root string, path string) (string, bool) {
+	root, err := filepath.Abs(root)
+	if err != nil || !is_inside_dir(root, path) {
+		return "", false
+	}
+	rel, _ := filepath.Rel(root, path)
+	return filepath.ToSlash(rel), true
+}
+
+// Returns path for comments of generated code instead of absolute host path.
+// Paths of API and standard library are relative to their roots,
+// other paths are relative to working directory if possible.
+func get_display_path(path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	rel, ok := get_rel_path(filepath.Dir(get_api_path()), path)
+	if ok {
+		return "api/" + rel
+	}
+
+	rel, ok = get_rel_path(build.PATH_STDLIB, path)
+	if ok {
+		return "std/" + rel
+	}
+
+	rel, ok = get_rel_path(build.PATH_WD, path)
+	if ok {
+		return rel
+	}
+	return filepath.Base(path)
+}
+
+// Returns command with display paths instead of absolute host paths.
+func get_display_cmd(cmd string) string {
+	args := strings.Fields(cmd)
+	for i, arg := range args {
+		args[i] = get_display_path(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// Header amalgamator.
+// Inlines local headers in dependency order.
+// Each header inlined only once.
+type _Amalgamator struct {
+	included map[string]bool
+}
+
+func new_amalgamator() *_Amalgamator {
+	return &_Amalgamator{
+		included: map[string]bool{},
+	}
+}
+
+// Returns content of header with inlined local includes.
+// Returns empty string if header already inlined.
+func (a *_Amalgamator) header(path string) string {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		exit_err("amalgamation failed: " + err.Error())
+	}
+
+	if a.included[path] {
+		return ""
+	}
+	a.included[path] = true
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		exit_err("amalgamation failed: " + err.Error())
+	}
+
+	dir := filepath.Dir(path)
+	lines := strings.Split(string(bytes), "\n")
+
+	var sb strings.Builder
+	sb.WriteString("// Amalgamated: ")
+	sb.WriteString(get_display_path(path))
+	sb.WriteByte('\n')
+	for _, line := range lines {
+		include := get_local_include(line)
+		if include == "" {
+			sb.WriteString(line)
+			sb.WriteByte('\n')
+			continue
+		}
+
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(dir, include)
+		}
+		sb.WriteString(a.header(include))
+	}
+
+	return sb.String()
+}
+
+// Returns include path of API header.
+func get_api_path() string {
+	if API_PATH != "" {
+		return API_PATH
+	}
+	return build.PATH_API
+}
@@ -0,0 +1,296 @@
+package cxx
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/julelang/jule/build"
+)
+
+const EMIT_CMAKE = "cmake"
+const EMIT_MAKE = "make"
+const EMIT_COMPILE_COMMANDS = "compile_commands"
+
+// Build file kind to emit.
+// Emits nothing if empty.
+// Sets by command-line inputs.
+var EMIT_BUILD = ""
+
+// Default executable name for build files if OUT is empty.
+const DEFAULT_BUILD_OUT = "main"
+
+// Entry of compile_commands.json.
+type _CompileCommand struct {
+	Directory string   `json:"directory"`
+	File      string   `json:"file"`
+	Arguments []string `json:"arguments"`
+}
+
+// Reports whether flag is a linker flag.
+// Flags should be grouped with their arguments.
+func is_link_flag(flag string) bool {
+	return strings.HasPrefix(flag, "-l") ||
+		strings.HasPrefix(flag, "-L") ||
+		strings.HasPrefix(flag, "-Wl,") ||
+		strings.HasPrefix(flag, "-Xlinker ") ||
+		strings.HasPrefix(flag, "-framework")
+}
+
+// Reports whether flag sets C++ standard.
+func is_std_flag(flag string) bool {
+	return strings.HasPrefix(flag, "--std=") || strings.HasPrefix(flag, "-std=")
+}
+
+// Returns C++ standard of compile flags for CMake, such as "14".
+// Last standard flag is used like compilers.
+// Reports whether standard has GNU extensions.
+func get_cmake_std(flags []string) (std string, gnu bool) {
+	for _, flag := range flags {
+		if is_std_flag(flag) {
+			_, std, _ = strings.Cut(flag, "=")
+		}
+	}
+	gnu = strings.HasPrefix(std, "gnu")
+	_, std, _ = strings.Cut(std, "++")
+	return
+}
+
+// Returns flag for CMake option lists.
+// Grouped flags are not splitted by CMake with SHELL prefix.
+func cmake_flag(flag string) string {
+	if strings.Contains(flag, " ") {
+		return "\"SHELL:" + flag + "\""
+	}
+	return flag
+}
+
+// Returns CMake target command and library type by build mode.
+func get_cmake_target_kind() (string, string) {
+	switch BUILDMODE {
+	case BUILDMODE_C_SHARED:
+		return "add_library", "SHARED"
+
+	case BUILDMODE_C_ARCHIVE:
+		return "add_library", "STATIC"
+
+	case BUILDMODE_OBJECT:
+		return "add_library", "OBJECT"
+
+	default:
+		return "add_executable", ""
+	}
+}
+
+// Returns name of CMake target.
+// Library targets have not lib prefix, CMake adds it.
+func get_cmake_target() string {
+	out := get_build_out()
+	switch BUILDMODE {
+	case BUILDMODE_C_SHARED:
+		out = get_shared_out()
+
+	case BUILDMODE_C_ARCHIVE:
+		out = get_archive_out()
+	}
+
+	target := filepath.Base(out)
+	target = strings.TrimSuffix(target, filepath.Ext(target))
+	if is_lib_buildmode() && BUILDMODE != BUILDMODE_OBJECT {
+		target = strings.TrimPrefix(target, "lib")
+	}
+	return target
+}
+
+// Returns name of executable for build files.
+func get_build_out() string {
+	if OUT != "" {
+		return OUT
+	}
+	return DEFAULT_BUILD_OUT
+}
+
+// Generates compile_commands.json content.
+// Each source has own entry, linked sources have own flags.
+func gen_compile_commands(sources []*_Source, passes []string) string {
+	args := []string{COMPILER_PATH}
+	for _, flag := range get_compile_flags(passes) {
+		if !is_link_flag(flag) {
+			args = append(args, strings.Fields(flag)...)
+		}
+	}
+	args = append(args, "-c", get_compile_path())
+
+	commands := []_CompileCommand{{
+		Directory: build.PATH_WD,
+		File:      get_compile_path(),
+		Arguments: args,
+	}}
+	for _, s := range sources {
+		args := []string{COMPILER_PATH}
+		args = append(args, s.flags...)
+		args = append(args, "-c", s.path)
+
+		commands = append(commands, _CompileCommand{
+			Directory: build.PATH_WD,
+			File:      s.path,
+			Arguments: args,
+		})
+	}
+
+	bytes, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		exit_err(err.Error())
+	}
+	return string(bytes) + "\n"
+}
+
+// Generates Makefile content.
+// Linked sources are compiled into objects by own rules.
+// Output is built by build mode, same with build commands.
+func gen_makefile(sources []*_Source, passes []string) string {
+	var sb strings.Builder
+	sb.WriteString("# Auto generated by JuleC.\n\n")
+	sb.WriteString("CXX = " + COMPILER_PATH + "\n")
+
+	sb.WriteString("CXXFLAGS =")
+	var link_flags []string
+	for _, flag := range get_compile_flags(passes) {
+		if is_link_flag(flag) {
+			link_flags = append(link_flags, flag)
+			continue
+		}
+		sb.WriteString(" " + flag)
+	}
+	sb.WriteByte('\n')
+
+	sb.WriteString("LDFLAGS =")
+	for _, flag := range link_flags {
+		sb.WriteString(" " + flag)
+	}
+	sb.WriteByte('\n')
+
+	sb.WriteString("SOURCE = " + get_compile_path() + "\n")
+	sb.WriteString("OBJECTS =")
+	for _, s := range sources {
+		sb.WriteString(" " + s.object)
+	}
+	sb.WriteByte('\n')
+	sb.WriteString("OUT = " + get_buildmode_out(get_compile_path()) + "\n")
+
+	cxx := "$(CXX)"
+	for _, flag := range get_buildmode_flags() {
+		cxx += " " + flag
+	}
+
+	clean := "$(OUT) $(OBJECTS)"
+	switch BUILDMODE {
+	case BUILDMODE_C_ARCHIVE:
+		sb.WriteString("AR = " + ARCHIVER + "\n")
+		sb.WriteString("IR_OBJECT = " + get_object_path(get_compile_path()) + "\n\n")
+		sb.WriteString("$(OUT): $(IR_OBJECT) $(OBJECTS)\n")
+		sb.WriteString("\t$(AR) rcs $(OUT) $(IR_OBJECT) $(OBJECTS)\n\n")
+		sb.WriteString("$(IR_OBJECT): $(SOURCE)\n")
+		sb.WriteString("\t" + cxx + " $(CXXFLAGS) -o $@ $<\n\n")
+		clean = "$(OUT) $(IR_OBJECT) $(OBJECTS)"
+
+	case BUILDMODE_OBJECT:
+		sb.WriteString("\n$(OUT): $(SOURCE) $(OBJECTS)\n")
+		sb.WriteString("\t" + cxx + " $(CXXFLAGS) -o $(OUT) $(SOURCE)\n\n")
+
+	default:
+		sb.WriteString("\n$(OUT): $(SOURCE) $(OBJECTS)\n")
+		sb.WriteString("\t" + cxx + " $(CXXFLAGS) $(SOURCE) $(OBJECTS) $(LDFLAGS) -o $(OUT)\n\n")
+	}
+	for _, s := range sources {
+		sb.WriteString(s.object + ": " + s.path + "\n")
+		sb.WriteString("\t@mkdir -p $(@D)\n")
+		sb.WriteString("\t$(CXX) -c")
+		for _, flag := range s.flags {
+			sb.WriteString(" " + flag)
+		}
+		sb.WriteString(" -o $@ $<\n\n")
+	}
+	sb.WriteString(".PHONY: clean\n")
+	sb.WriteString("clean:\n")
+	sb.WriteString("\trm -f " + clean + "\n")
+	return sb.String()
+}
+
+// Generates CMakeLists.txt content.
+// Flags of linked sources are set as source properties.
+// Target kind and C++ standard are same with build mode and compile flags.
+func gen_cmake(sources []*_Source, passes []string) string {
+	target := get_cmake_target()
+	std, gnu := get_cmake_std(get_compile_flags(passes))
+
+	var sb strings.Builder
+	sb.WriteString("# Auto generated by JuleC.\n\n")
+	sb.WriteString("cmake_minimum_required(VERSION 3.13)\n")
+	sb.WriteString("project(" + target + " CXX)\n\n")
+	sb.WriteString("set(CMAKE_CXX_STANDARD " + std + ")\n")
+	sb.WriteString("set(CMAKE_CXX_STANDARD_REQUIRED ON)\n")
+	if gnu {
+		sb.WriteString("set(CMAKE_CXX_EXTENSIONS ON)\n\n")
+	} else {
+		sb.WriteString("set(CMAKE_CXX_EXTENSIONS OFF)\n\n")
+	}
+
+	cmd, kind := get_cmake_target_kind()
+	sb.WriteString(cmd + "(" + target)
+	if kind != "" {
+		sb.WriteString(" " + kind)
+	}
+	sb.WriteString("\n\t\"" + filepath.ToSlash(get_compile_path()) + "\"")
+	for _, s := range sources {
+		sb.WriteString("\n\t\"" + filepath.ToSlash(s.path) + "\"")
+	}
+	sb.WriteString("\n)\n")
+
+	for _, s := range sources {
+		if len(s.flags) > 0 {
+			sb.WriteString("set_source_files_properties(\"" + filepath.ToSlash(s.path) + "\" PROPERTIES COMPILE_OPTIONS \"" + strings.Join(s.flags, ";") + "\")\n")
+		}
+	}
+
+	if BUILDMODE == BUILDMODE_C_ARCHIVE {
+		sb.WriteString("set_target_properties(" + target + " PROPERTIES POSITION_INDEPENDENT_CODE ON)\n")
+	}
+
+	var compile_flags []string
+	var link_flags []string
+	for _, pass := range split_passes(passes) {
+		switch {
+		case is_std_flag(pass):
+			// Set by CMAKE_CXX_STANDARD.
+		case is_link_flag(pass):
+			link_flags = append(link_flags, cmake_flag(pass))
+		default:
+			compile_flags = append(compile_flags, cmake_flag(pass))
+		}
+	}
+
+	if len(compile_flags) > 0 {
+		sb.WriteString("target_compile_options(" + target + " PRIVATE " + strings.Join(compile_flags, " ") + ")\n")
+	}
+	if len(link_flags) > 0 {
+		sb.WriteString("target_link_options(" + target + " PRIVATE " + strings.Join(link_flags, " ") + ")\n")
+	}
+
+	return sb.String()
+}
+
+// Writes build files to output directory.
+func emit_build(sources []*_Source, passes []string) {
+	dir := filepath.Dir(get_compile_path())
+	switch EMIT_BUILD {
+	case EMIT_COMPILE_COMMANDS:
+		write_output(filepath.Join(dir, "compile_commands.json"), gen_compile_commands(sources, passes))
+
+	case EMIT_MAKE:
+		write_output(filepath.Join(dir, "Makefile"), gen_makefile(sources, passes))
+
+	case EMIT_CMAKE:
+		write_output(filepath.Join(dir, "CMakeLists.txt"), gen_cmake(sources, passes))
+	}
+}
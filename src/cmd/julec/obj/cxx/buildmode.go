@@ -0,0 +1,185 @@
+package cxx
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const BUILDMODE_EXE = "exe"
+const BUILDMODE_C_ARCHIVE = "c-archive"
+const BUILDMODE_C_SHARED = "c-shared"
+const BUILDMODE_OBJECT = "object"
+
+// Build mode of output.
+// Sets by command-line inputs.
+var BUILDMODE = BUILDMODE_EXE
+
+// Identifier of library initializer function.
+// Library build modes emits this function instead of C++ main.
+const LIB_INIT_IDENT = "jule_init"
+
+// Archiver for static libraries.
+const ARCHIVER = "ar"
+
+// Base name of library outputs if OUT is empty.
+const DEFAULT_LIB_NAME = "jule"
+
+// Reports whether build mode is library mode.
+// Library modes not requires entry point.
+func is_lib_buildmode() bool { return BUILDMODE != BUILDMODE_EXE }
+
+// Returns output path of shared library.
+func get_shared_out() string {
+	if OUT != "" {
+		return OUT
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return DEFAULT_LIB_NAME + ".dll"
+
+	case "darwin":
+		return "lib" + DEFAULT_LIB_NAME + ".dylib"
+
+	default:
+		return "lib" + DEFAULT_LIB_NAME + ".so"
+	}
+}
+
+// Returns output path of static library.
+func get_archive_out() string {
+	if OUT != "" {
+		return OUT
+	}
+	return "lib" + DEFAULT_LIB_NAME + ".a"
+}
+
+// Returns object file path of source file.
+// Objects are placed into output directory.
+func get_object_path(source_path string) string {
+	name := filepath.Base(source_path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	return filepath.Join(filepath.Dir(get_compile_path()), name+".o")
+}
+
+// Returns output path by build mode.
+// Output of c-archive mode is archive of object files.
+func get_buildmode_out(source_path string) string {
+	switch BUILDMODE {
+	case BUILDMODE_C_SHARED:
+		return get_shared_out()
+
+	case BUILDMODE_C_ARCHIVE:
+		return get_archive_out()
+
+	case BUILDMODE_OBJECT:
+		if OUT != "" {
+			return OUT
+		}
+		return get_object_path(source_path)
+
+	default:
+		return get_build_out()
+	}
+}
+
+// Returns compiler flags of IR by build mode.
+func get_buildmode_flags() []string {
+	switch BUILDMODE {
+	case BUILDMODE_C_SHARED:
+		return []string{"-shared", "-fPIC"}
+
+	case BUILDMODE_C_ARCHIVE:
+		return []string{"-c", "-fPIC"}
+
+	case BUILDMODE_OBJECT:
+		return []string{"-c"}
+
+	default:
+		return nil
+	}
+}
+
+// Returns command to create static library from object files.
+func gen_archive_cmd(out string, objects []string) string {
+	return ARCHIVER + " rcs " + out + " " + strings.Join(objects, " ")
+}
+
+// Returns compile command of source file to object file.
+func gen_object_cmd(source_path string, object_path string, passes []string) string {
+	cmd := COMPILER_PATH + " "
+	for _, flag := range get_buildmode_flags() {
+		cmd += flag + " "
+	}
+
+	for _, flag := range get_compile_flags(passes) {
+		if !is_link_flag(flag) {
+			cmd += flag + " "
+		}
+	}
+
+	cmd += "-o " + object_path + " "
+	cmd += source_path
+	return cmd
+}
+
+// Returns all commands to build output by build mode.
+// Each command is a program path and arguments separated by space.
+// Linked sources are compiled first if not cached.
+func gen_build_cmds(source_path string, sources []*_Source, passes []string) []string {
+	cmds := gen_source_cmds(sources)
+	objects := get_source_objects(sources)
+
+	switch BUILDMODE {
+	case BUILDMODE_C_SHARED:
+		compiler, cmd := gen_compile_cmd(source_path, objects, passes)
+		if OUT == "" {
+			cmd = "-o " + get_shared_out() + " " + cmd
+		}
+		return append(cmds, compiler+" "+strings.Join(get_buildmode_flags(), " ")+" "+cmd)
+
+	case BUILDMODE_OBJECT:
+		object := get_buildmode_out(source_path)
+		return append(cmds, gen_object_cmd(source_path, object, passes))
+
+	case BUILDMODE_C_ARCHIVE:
+		object := get_object_path(source_path)
+		cmds = append(cmds, gen_object_cmd(source_path, object, passes))
+		objects = append([]string{object}, objects...)
+		return append(cmds, gen_archive_cmd(get_buildmode_out(source_path), objects))
+
+	default:
+		compiler, cmd := gen_compile_cmd(source_path, objects, passes)
+		return append(cmds, compiler+" "+cmd)
+	}
+}
+
+// Generates C++ code of program entry by build mode.
+// Executables have C++ main function, libraries have initializer function.
+func gen_entry() string {
+	if is_lib_buildmode() {
+		return `
+extern "C" void ` + LIB_INIT_IDENT + `(void) {
+	static bool initialized = false;
+	if (initialized)
+		return;
+	initialized = true;
+
+	std::set_terminate(&jule::terminate_handler);
+	jule::set_sig_handler(jule::signal_handler);
+	__jule_call_initializers();
+}`
+	}
+
+	return `
+int main(int argc, char *argv[]) {
+	std::set_terminate(&jule::terminate_handler);
+	jule::set_sig_handler(jule::signal_handler);
+	jule::setup_command_line_args(argc, argv);
+	__jule_call_initializers();
+	entry_point();
+
+	return EXIT_SUCCESS;
+}`
+}
@@ -33,6 +33,14 @@ var MODE = MODE_C
 var OUT_NAME = "ir.cpp"
 var OUT = ""
 
+// Reports warnings as errors if true.
+// Sets by command-line inputs.
+var WERROR = false
+
+// Format of printed logs.
+// Sets by command-line inputs.
+var DIAG_FORMAT = build.DIAG_FORMAT_RICH
+
 func exit_err(msg string) {
 	const ERROR_EXIT_CODE = 0
 
@@ -146,16 +154,49 @@ func check_compiler() {
 func set() {
 	check_mode()
 	check_compiler()
+
+	if WERROR {
+		build.Warnings_as_errors()
+	}
 }
 
-// print_logs prints logs and returns true
-// if logs has error, false if not.
+// Returns renderer of logs for DIAG_FORMAT.
+// Returns nil for short and JSON formats.
+// Logs are colored if stderr is terminal and NO_COLOR is not set.
+func get_renderer() *build.Renderer {
+	if DIAG_FORMAT != build.DIAG_FORMAT_RICH {
+		return nil
+	}
+	color := build.Is_tty(os.Stderr) && os.Getenv("NO_COLOR") == ""
+	return build.New_renderer(color)
+}
+
+// Prints logs with summary of errors and warnings.
+// Summary is not printed for JSON format.
 func print_logs(logs []build.Log) {
+	if DIAG_FORMAT == build.DIAG_FORMAT_JSON {
+		if len(logs) > 0 {
+			println(build.Render_json(logs))
+		}
+		return
+	}
+
+	renderer := get_renderer()
 	var str strings.Builder
 	for _, l := range logs {
-		str.WriteString(l.String())
+		if renderer != nil {
+			str.WriteString(renderer.Render(l))
+		} else {
+			str.WriteString(l.String())
+		}
 		str.WriteByte('\n')
 	}
+
+	summary := build.Summary(logs)
+	if summary != "" {
+		str.WriteString(summary)
+		str.WriteString(" generated\n")
+	}
 	print(str.String())
 }
 
@@ -176,37 +217,55 @@ func write_output(path, content string) {
 	_ = f.Close()
 }
 
-func compile(path string) (*sema.Package, *Importer) {
+// Imports and analyzes package, returns logs of analysis.
+// Returns nil package if analysis fails.
+func check(path string) (*sema.Package, *Importer, []build.Log) {
 	set()
 
 	// Check standard library.
 	inf, err := os.Stat(build.PATH_STDLIB)
 	if err != nil || !inf.IsDir() {
 		exit_err(build.Errorf("stdlib_not_exist"))
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	importer := &Importer{}
 	files, errors := importer.Import_package(path)
 	if len(errors) > 0 {
-		print_logs(errors)
-		return nil, nil
+		return nil, nil, errors
 	}
 
 	if len(files) == 0 {
 		exit_err(build.Errorf("no_file_in_entry_package", path))
 	}
 
-	pkg, errors := sema.Analyze_package(files, importer)
-	if len(errors) > 0 {
-		print_logs(errors)
+	pkg, logs := sema.Analyze_package(files, importer)
+	if pkg == nil {
+		return nil, nil, logs
+	}
+	return pkg, importer, logs
+}
+
+// Imports and analyzes package, prints logs of analysis.
+// Returns nil package if analysis fails.
+func analyze(path string) (*sema.Package, *Importer) {
+	pkg, importer, logs := check(path)
+	print_logs(logs)
+	return pkg, importer
+}
+
+func compile(path string) (*sema.Package, *Importer) {
+	pkg, importer := analyze(path)
+	if pkg == nil {
 		return nil, nil
 	}
 
-	const CPP_LINKED = false
-	f := pkg.Find_fn(build.ENTRY_POINT, CPP_LINKED)
-	if f == nil {
-		exit_err(build.Errorf("no_entry_point"))
+	if !is_lib_buildmode() {
+		const CPP_LINKED = false
+		f := pkg.Find_fn(build.ENTRY_POINT, CPP_LINKED)
+		if f == nil {
+			exit_err(build.Errorf("no_entry_point"))
+		}
 	}
 
 	return pkg, importer
@@ -228,34 +287,54 @@ func is_cpp_source_file(path string) bool {
 	return build.Is_valid_cpp_ext(path[offset:])
 }
 
-func gen_compile_cmd(source_path string, used []*sema.ImportInfo, passes []string) (string, string) {
-	compiler := COMPILER_PATH
-
+// Returns compiler flags of IR compilation.
+// Passes are splitted into flags.
+func get_compile_flags(passes []string) []string {
 	const ZERO_LEVEL_OPTIMIZATION = "-O0"
 	const DISABLE_ALL_WARNINGS = "-Wno-everything"
 	const SET_STD = "--std=c++14"
 
-	cmd := ZERO_LEVEL_OPTIMIZATION + " "
-	cmd += DISABLE_ALL_WARNINGS + " "
-	cmd += SET_STD + " "
-
-	// Push passes.
-	for _, pass := range passes {
-		cmd += pass + " "
+	flags := []string{
+		ZERO_LEVEL_OPTIMIZATION,
+		DISABLE_ALL_WARNINGS,
+		SET_STD,
 	}
+	flags = append(flags, split_passes(passes)...)
+	return flags
+}
+
+// Returns compiler and arguments to compile IR and link with objects.
+func gen_compile_cmd(source_path string, objects []string, passes []string) (string, string) {
+	compiler := COMPILER_PATH
 
-	// Push linked source files.
-	for _, u := range used {
-		if u.Cpp_linked && is_cpp_source_file(u.Path) {
-			cmd += u.Path + " "
+	cmd := ""
+
+	// Push flags and passes.
+	// Linker flags must placed after sources.
+	flags := get_compile_flags(passes)
+	for _, flag := range flags {
+		if !is_link_flag(flag) {
+			cmd += flag + " "
 		}
 	}
 
+	// Push objects of linked source files.
+	for _, object := range objects {
+		cmd += object + " "
+	}
+
 	if OUT != "" {
 		cmd += "-o " + OUT + " "
 	}
 	cmd += source_path
 
+	// Push linker flags.
+	for _, flag := range flags {
+		if is_link_flag(flag) {
+			cmd += " " + flag
+		}
+	}
+
 	return compiler, cmd
 }
 
@@ -265,22 +344,29 @@ func get_compile_path() string {
 	return path
 }
 
-func do_spell(obj string, compiler string, compiler_cmd string) {
+// Writes IR and runs build commands if mode is compile.
+// Reports whether all commands succeeded.
+func do_spell(obj string, cmds []string) bool {
 	path := get_compile_path()
 	write_output(path, obj)
 	switch MODE {
 	case MODE_C:
-		entries := strings.SplitN(compiler_cmd, " ", -1)
-		command := exec.Command(compiler, entries...)
-		err := command.Start()
-		if err != nil {
-			println(err.Error())
-		}
-		err = command.Wait()
-		if err != nil {
-			println(err.Error())
+		for _, cmd := range cmds {
+			entries := strings.SplitN(cmd, " ", -1)
+			command := exec.Command(entries[0], entries[1:]...)
+			err := command.Start()
+			if err != nil {
+				println(err.Error())
+				return false
+			}
+			err = command.Wait()
+			if err != nil {
+				println(err.Error())
+				return false
+			}
 		}
 	}
+	return true
 }
 
 func get_all_unique_passes(pkg *sema.Package, uses []*sema.ImportInfo) []string {
@@ -314,19 +400,55 @@ func get_all_unique_passes(pkg *sema.Package, uses []*sema.ImportInfo) []string
 	return passes
 }
 
+// Splits passes into flags.
+// Flags are grouped with their separate arguments.
+func split_passes(passes []string) []string {
+	var flags []string
+	for _, pass := range passes {
+		flags = append(flags, strings.Fields(pass)...)
+	}
+	return group_flags(flags)
+}
+
 func Compile(path string) {
 	pkg, importer := compile(path)
 	if pkg == nil || importer == nil {
 		return
 	}
 
+	manifest := read_link_manifest(get_link_manifest_path(path))
+	if !check_policy(path, pkg, importer.all_packages, manifest) {
+		return
+	}
+
 	passes := get_all_unique_passes(pkg, importer.all_packages)
-	compiler, compiler_cmd := gen_compile_cmd(get_compile_path(), importer.all_packages, passes)
+	passes = append(passes, resolve_links(manifest, pkg, importer.all_packages)...)
+	passes = dedup_flags(split_passes(passes))
+	sources := get_linked_sources(importer.all_packages, manifest)
+	cmds := gen_build_cmds(get_compile_path(), sources, passes)
 
 	obj := Gen(pkg, importer.all_packages)
-	append_standard(&obj, compiler, compiler_cmd)
+	append_standard(&obj, cmds)
+
+	if DCE_REPORT && REACHABLE != nil {
+		print(get_dce_report(pkg, importer.all_packages))
+	}
+
+	if MODE == MODE_C {
+		make_obj_dir(sources)
+	}
+	if do_spell(obj, cmds) {
+		write_export_header(pkg, importer.all_packages)
+	}
+
+	if EMIT_BUILD != "" {
+		emit_build(sources, passes)
+	}
+}
 
-	do_spell(obj, compiler, compiler_cmd)
+// Analyzes package and prints logs without code generation.
+func Vet(path string) {
+	_, _ = analyze(path)
 }
 
 func init() {
@@ -0,0 +1,731 @@
+package cxx
+
+import (
+	"strconv"
+
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/sema"
+)
+
+// Dead code elimination.
+// Sets by command-line inputs.
+var DCE = true
+var DCE_REPORT = false
+
+// Reachable declarations of current generation.
+// Nil if dead code elimination is disabled.
+var REACHABLE *_Reachability = nil
+
+// Reachability analysis of whole program.
+// Collects function instances, structure instances, traits and globals
+// that reachable from roots. Roots are entry point, initializer functions,
+// exported functions and globals which have function calls in initializer
+// expression.
+type _Reachability struct {
+	fns     map[*sema.FnIns]bool
+	structs map[*sema.StructIns]bool
+	traits  map[*sema.Trait]bool
+	globals map[*sema.Var]bool
+
+	// Function instances waiting to be walked.
+	queue []*sema.FnIns
+
+	// Count of walked function calls.
+	calls int
+}
+
+func new_reachability() *_Reachability {
+	return &_Reachability{
+		fns:     map[*sema.FnIns]bool{},
+		structs: map[*sema.StructIns]bool{},
+		traits:  map[*sema.Trait]bool{},
+		globals: map[*sema.Var]bool{},
+	}
+}
+
+// Returns instance of declaration which is same with given instance.
+// Returns given instance if not exist.
+func canonical_fn_ins(f *sema.FnIns) *sema.FnIns {
+	if f.Decl == nil {
+		return f
+	}
+
+	for _, ins := range f.Decl.Instances {
+		if ins == f {
+			return ins
+		}
+	}
+
+	if len(f.Generics) == 0 {
+		if len(f.Decl.Instances) > 0 {
+			return f.Decl.Instances[0]
+		}
+		return f
+	}
+
+lookup:
+	for _, ins := range f.Decl.Instances {
+		if len(ins.Generics) != len(f.Generics) {
+			continue
+		}
+
+		for i, g := range ins.Generics {
+			if g == nil || f.Generics[i] == nil || g.To_str() != f.Generics[i].To_str() {
+				continue lookup
+			}
+		}
+
+		return ins
+	}
+
+	return f
+}
+
+// Returns instance of declaration which is same with given instance.
+// Returns given instance if not exist.
+func canonical_struct_ins(s *sema.StructIns) *sema.StructIns {
+	if len(s.Generics) == 0 {
+		if len(s.Decl.Instances) > 0 {
+			return s.Decl.Instances[0]
+		}
+		return s
+	}
+
+	kind := s.To_str()
+	for _, ins := range s.Decl.Instances {
+		if ins == s || ins.To_str() == kind {
+			return ins
+		}
+	}
+
+	return s
+}
+
+func (r *_Reachability) push_fn(f *sema.FnIns) {
+	if f == nil || f.Is_builtin() || f.Decl == nil || f.Decl.Cpp_linked {
+		return
+	}
+
+	if f.Is_anon() {
+		// Anonymous functions generated inline, walk body directly.
+		r.fn_types(f)
+		r.scope(f.Scope)
+		return
+	}
+
+	f = canonical_fn_ins(f)
+	if r.fns[f] {
+		return
+	}
+
+	r.fns[f] = true
+	r.queue = append(r.queue, f)
+}
+
+func (r *_Reachability) push_struct(s *sema.StructIns) {
+	if s == nil {
+		return
+	}
+
+	if s.Decl.Cpp_linked {
+		// Generics are template arguments of cpp-linked structures.
+		for _, g := range s.Generics {
+			r.kind(g)
+		}
+		return
+	}
+
+	s = canonical_struct_ins(s)
+	if r.structs[s] {
+		return
+	}
+	r.structs[s] = true
+
+	for _, f := range s.Fields {
+		r.kind(f.Kind)
+	}
+
+	// Traits are base classes of structure, so implemented trait
+	// methods are used by virtual table.
+	for _, t := range s.Decl.Implements {
+		r.push_trait(t)
+		for _, tf := range t.Methods {
+			m := s.Find_method(tf.Ident)
+			if m == nil {
+				continue
+			}
+
+			for _, ins := range m.Instances {
+				r.push_fn(ins)
+			}
+		}
+	}
+}
+
+func (r *_Reachability) push_trait(t *sema.Trait) {
+	if t == nil || r.traits[t] {
+		return
+	}
+	r.traits[t] = true
+
+	for _, f := range t.Methods {
+		for _, p := range f.Params {
+			if p.Kind != nil {
+				r.kind(p.Kind.Kind)
+			}
+		}
+
+		if !f.Is_void() && f.Result.Kind != nil {
+			r.kind(f.Result.Kind.Kind)
+		}
+	}
+}
+
+func (r *_Reachability) push_global(v *sema.Var) {
+	if v.Cpp_linked || v.Constant || v.Scope != nil || r.globals[v] {
+		return
+	}
+	r.globals[v] = true
+
+	if v.Kind != nil {
+		r.kind(v.Kind.Kind)
+	}
+
+	if v.Value != nil && v.Value.Data != nil {
+		r.expr(v.Value.Data.Model)
+	}
+}
+
+// Walks type kind.
+func (r *_Reachability) kind(k *sema.TypeKind) {
+	switch {
+	case k == nil:
+		return
+
+	case k.Cpp_linked:
+		for _, g := range k.Cpp_generics {
+			r.kind(g)
+		}
+
+	case k.Strct() != nil:
+		r.push_struct(k.Strct())
+
+	case k.Trt() != nil:
+		r.push_trait(k.Trt())
+
+	case k.Ref() != nil:
+		r.kind(k.Ref().Elem)
+
+	case k.Ptr() != nil:
+		r.kind(k.Ptr().Elem)
+
+	case k.Slc() != nil:
+		r.kind(k.Slc().Elem)
+
+	case k.Arr() != nil:
+		r.kind(k.Arr().Elem)
+
+	case k.Map() != nil:
+		r.kind(k.Map().Key)
+		r.kind(k.Map().Val)
+
+	case k.Tup() != nil:
+		for _, t := range k.Tup().Types {
+			r.kind(t)
+		}
+
+	case k.Enm() != nil:
+		if k.Enm().Kind != nil {
+			r.kind(k.Enm().Kind.Kind)
+		}
+
+	case k.Fnc() != nil:
+		r.fn_types(k.Fnc())
+	}
+}
+
+// Walks parameter and result types of function instance.
+func (r *_Reachability) fn_types(f *sema.FnIns) {
+	for _, p := range f.Params {
+		r.kind(p.Kind)
+	}
+	r.kind(f.Result)
+}
+
+func (r *_Reachability) exprs(models []sema.ExprModel) {
+	for _, m := range models {
+		r.expr(m)
+	}
+}
+
+// Walks expression model.
+func (r *_Reachability) expr(m sema.ExprModel) {
+	switch m.(type) {
+	case *sema.TypeKind:
+		r.kind(m.(*sema.TypeKind))
+
+	case *sema.Var:
+		v := m.(*sema.Var)
+		if v.Scope == nil {
+			r.push_global(v)
+		}
+
+	case *sema.Struct:
+		for _, ins := range m.(*sema.Struct).Instances {
+			r.push_struct(ins)
+		}
+
+	case *sema.StructIns:
+		r.push_struct(m.(*sema.StructIns))
+
+	case *sema.FnIns:
+		r.push_fn(m.(*sema.FnIns))
+
+	case *sema.BinopExprModel:
+		r.expr(m.(*sema.BinopExprModel).Left)
+		r.expr(m.(*sema.BinopExprModel).Right)
+
+	case *sema.UnaryExprModel:
+		r.expr(m.(*sema.UnaryExprModel).Expr)
+
+	case *sema.GetRefPtrExprModel:
+		r.expr(m.(*sema.GetRefPtrExprModel).Expr)
+
+	case *sema.StructLitExprModel:
+		lit := m.(*sema.StructLitExprModel)
+		r.push_struct(lit.Strct)
+		for _, arg := range lit.Args {
+			r.expr(arg.Expr)
+		}
+
+	case *sema.AllocStructLitExprModel:
+		r.expr(m.(*sema.AllocStructLitExprModel).Lit)
+
+	case *sema.CastingExprModel:
+		c := m.(*sema.CastingExprModel)
+		r.kind(c.Kind)
+		r.kind(c.ExprKind)
+		r.expr(c.Expr)
+
+	case *sema.FnCallExprModel:
+		fc := m.(*sema.FnCallExprModel)
+		r.calls++
+		r.push_fn(fc.Func)
+		r.expr(fc.Expr)
+		r.exprs(fc.Args)
+
+	case *sema.SliceExprModel:
+		r.kind(m.(*sema.SliceExprModel).Elem_kind)
+		r.exprs(m.(*sema.SliceExprModel).Elems)
+
+	case *sema.ArrayExprModel:
+		a := m.(*sema.ArrayExprModel)
+		r.kind(a.Kind.Elem)
+		r.exprs(a.Elems)
+
+	case *sema.IndexigExprModel:
+		r.expr(m.(*sema.IndexigExprModel).Expr)
+		r.expr(m.(*sema.IndexigExprModel).Index)
+
+	case *sema.AnonFnExprModel:
+		r.push_fn(m.(*sema.AnonFnExprModel).Func)
+
+	case *sema.MapExprModel:
+		mp := m.(*sema.MapExprModel)
+		r.kind(mp.Key_kind)
+		r.kind(mp.Val_kind)
+		for _, pair := range mp.Entries {
+			r.expr(pair.Key)
+			r.expr(pair.Val)
+		}
+
+	case *sema.SlicingExprModel:
+		s := m.(*sema.SlicingExprModel)
+		r.expr(s.Expr)
+		r.expr(s.Left)
+		r.expr(s.Right)
+
+	case *sema.TraitSubIdentExprModel:
+		r.expr(m.(*sema.TraitSubIdentExprModel).Expr)
+
+	case *sema.StructSubIdentExprModel:
+		si := m.(*sema.StructSubIdentExprModel)
+		r.kind(si.ExprKind)
+		r.expr(si.Expr)
+		if si.Method != nil {
+			r.push_fn(si.Method)
+		}
+
+	case *sema.CommonSubIdentExprModel:
+		r.expr(m.(*sema.CommonSubIdentExprModel).Expr)
+
+	case *sema.TupleExprModel:
+		for _, d := range m.(*sema.TupleExprModel).Datas {
+			r.expr(d.Model)
+		}
+
+	case *sema.BuiltinOutCallExprModel:
+		r.expr(m.(*sema.BuiltinOutCallExprModel).Expr)
+
+	case *sema.BuiltinOutlnCallExprModel:
+		r.expr(m.(*sema.BuiltinOutlnCallExprModel).Expr)
+
+	case *sema.BuiltinNewCallExprModel:
+		r.kind(m.(*sema.BuiltinNewCallExprModel).Kind)
+		r.expr(m.(*sema.BuiltinNewCallExprModel).Init)
+
+	case *sema.BuiltinRealCallExprModel:
+		r.expr(m.(*sema.BuiltinRealCallExprModel).Expr)
+
+	case *sema.BuiltinDropCallExprModel:
+		r.expr(m.(*sema.BuiltinDropCallExprModel).Expr)
+
+	case *sema.BuiltinPanicCallExprModel:
+		r.expr(m.(*sema.BuiltinPanicCallExprModel).Expr)
+
+	case *sema.BuiltinMakeCallExprModel:
+		r.kind(m.(*sema.BuiltinMakeCallExprModel).Kind)
+		r.expr(m.(*sema.BuiltinMakeCallExprModel).Size)
+
+	case *sema.BuiltinCloneCallExprModel:
+		r.expr(m.(*sema.BuiltinCloneCallExprModel).Expr)
+
+	case *sema.SizeofExprModel:
+		r.expr(m.(*sema.SizeofExprModel).Expr)
+
+	case *sema.AlignofExprModel:
+		r.expr(m.(*sema.AlignofExprModel).Expr)
+
+	case *sema.StrConstructorCallExprModel:
+		r.expr(m.(*sema.StrConstructorCallExprModel).Expr)
+
+	case *sema.BuiltinErrorTraitSubIdentExprModel:
+		r.expr(m.(*sema.BuiltinErrorTraitSubIdentExprModel).Expr)
+
+	case *sema.ExplicitDerefExprModel:
+		r.expr(m.(*sema.ExplicitDerefExprModel).Expr)
+
+	case *sema.CFnptrExprModel:
+		r.push_fn(m.(*sema.CFnptrExprModel).Func)
+	}
+}
+
+func (r *_Reachability) data(d *sema.Data) {
+	if d != nil {
+		r.kind(d.Kind)
+		r.expr(d.Model)
+	}
+}
+
+// Walks statement.
+func (r *_Reachability) st(st sema.St) {
+	switch st.(type) {
+	case *sema.Scope:
+		r.scope(st.(*sema.Scope))
+
+	case *sema.Var:
+		v := st.(*sema.Var)
+		if v == nil {
+			return
+		}
+		if v.Kind != nil {
+			r.kind(v.Kind.Kind)
+		}
+		if v.Value != nil {
+			r.data(v.Value.Data)
+		}
+
+	case *sema.Data:
+		r.data(st.(*sema.Data))
+
+	case *sema.Conditional:
+		c := st.(*sema.Conditional)
+		for _, elif := range c.Elifs {
+			if elif != nil {
+				r.expr(elif.Expr)
+				r.scope(elif.Scope)
+			}
+		}
+		if c.Default != nil {
+			r.scope(c.Default.Scope)
+		}
+
+	case *sema.InfIter:
+		r.scope(st.(*sema.InfIter).Scope)
+
+	case *sema.WhileIter:
+		it := st.(*sema.WhileIter)
+		r.expr(it.Expr)
+		r.st(it.Next)
+		r.scope(it.Scope)
+
+	case *sema.RangeIter:
+		it := st.(*sema.RangeIter)
+		r.data(it.Expr)
+		r.st(it.Key_a)
+		r.st(it.Key_b)
+		r.scope(it.Scope)
+
+	case *sema.Postfix:
+		r.expr(st.(*sema.Postfix).Expr)
+
+	case *sema.Assign:
+		r.expr(st.(*sema.Assign).L)
+		r.expr(st.(*sema.Assign).R)
+
+	case *sema.MultiAssign:
+		a := st.(*sema.MultiAssign)
+		for _, l := range a.L {
+			switch l.(type) {
+			case *sema.Var:
+				r.st(l)
+
+			default:
+				r.expr(l)
+			}
+		}
+		r.expr(a.R)
+
+	case *sema.Match:
+		m := st.(*sema.Match)
+		r.expr(m.Expr)
+		for _, c := range m.Cases {
+			if c != nil {
+				r.exprs(c.Exprs)
+				r.scope(c.Scope)
+			}
+		}
+		if m.Default != nil {
+			r.scope(m.Default.Scope)
+		}
+
+	case *sema.RetSt:
+		ret := st.(*sema.RetSt)
+		for _, v := range ret.Vars {
+			r.st(v)
+		}
+		r.expr(ret.Expr)
+
+	case *sema.Recover:
+		rec := st.(*sema.Recover)
+		r.push_fn(rec.Handler)
+		r.expr(rec.Handler_expr)
+		r.scope(rec.Scope)
+	}
+}
+
+// Walks scope.
+func (r *_Reachability) scope(s *sema.Scope) {
+	if s == nil {
+		return
+	}
+
+	for _, st := range s.Stmts {
+		r.st(st)
+	}
+}
+
+// Walks function instance.
+func (r *_Reachability) fn(f *sema.FnIns) {
+	r.fn_types(f)
+	if f.Owner != nil {
+		r.push_struct(f.Owner)
+	}
+	r.scope(f.Scope)
+}
+
+// Walks queued function instances until all reachable declarations collected.
+func (r *_Reachability) flush() {
+	for len(r.queue) > 0 {
+		f := r.queue[0]
+		r.queue = r.queue[1:]
+		r.fn(f)
+	}
+}
+
+// Reports whether global variable initialization has function calls.
+// These variables accepted as root, because initialization may have side effects.
+func has_call_init(v *sema.Var) bool {
+	if v.Value == nil || v.Value.Data == nil {
+		return false
+	}
+
+	probe := new_reachability()
+	probe.expr(v.Value.Data.Model)
+	return probe.calls > 0
+}
+
+func (r *_Reachability) push_pkg_roots(pkg *sema.Package, main bool) {
+	const CPP_LINKED = false
+
+	if main {
+		for _, f := range pkg.Files {
+			for _, fn := range f.Funcs {
+				if !fn.Cpp_linked && fn.Ident == build.ENTRY_POINT {
+					for _, ins := range fn.Instances {
+						r.push_fn(ins)
+					}
+				}
+			}
+		}
+	}
+
+	f := pkg.Find_fn(build.INIT_FN, CPP_LINKED)
+	if f != nil {
+		for _, ins := range f.Instances {
+			r.push_fn(ins)
+		}
+	}
+
+	// Exported functions are used by foreign code.
+	for _, file := range pkg.Files {
+		for _, f := range file.Funcs {
+			if !f.Cpp_linked && f.Export_ident() != "" {
+				for _, ins := range f.Instances {
+					r.push_fn(ins)
+				}
+			}
+		}
+	}
+
+	for _, file := range pkg.Files {
+		for _, v := range file.Vars {
+			if !lex.Is_ignore_ident(v.Ident) && has_call_init(v) {
+				r.push_global(v)
+			}
+		}
+	}
+}
+
+// Returns reachable declarations of program.
+func analyze_reachability(pkg *sema.Package, used []*sema.ImportInfo) *_Reachability {
+	r := new_reachability()
+
+	r.push_pkg_roots(pkg, true)
+	for _, u := range used {
+		if !u.Cpp_linked {
+			r.push_pkg_roots(u.Package, false)
+		}
+	}
+
+	r.flush()
+	return r
+}
+
+// Reports whether function instance is reachable.
+// Reports true always if dead code elimination is disabled.
+func is_reachable_fn(f *sema.FnIns) bool {
+	return REACHABLE == nil || REACHABLE.fns[f]
+}
+
+// Reports whether structure instance is reachable.
+// Reports true always if dead code elimination is disabled.
+func is_reachable_struct(s *sema.StructIns) bool {
+	return REACHABLE == nil || REACHABLE.structs[s]
+}
+
+// Reports whether trait is reachable.
+// Reports true always if dead code elimination is disabled.
+func is_reachable_trait(t *sema.Trait) bool {
+	return REACHABLE == nil || REACHABLE.traits[t]
+}
+
+// Reports whether global variable is reachable.
+// Reports true always if dead code elimination is disabled.
+func is_reachable_global(v *sema.Var) bool {
+	return REACHABLE == nil || REACHABLE.globals[v]
+}
+
+// Returns identifier of function instance for reports.
+func get_fn_ins_report_ident(f *sema.FnIns) string {
+	ident := f.Decl.Ident
+	if f.Owner != nil {
+		ident = f.Owner.To_str() + "." + ident
+	}
+
+	if len(f.Generics) > 0 {
+		ident += "["
+		for i, g := range f.Generics {
+			ident += g.To_str()
+			if i+1 < len(f.Generics) {
+				ident += ","
+			}
+		}
+		ident += "]"
+	}
+
+	return ident
+}
+
+func get_decl_pos(token lex.Token) string {
+	if token.File == nil {
+		return "<built-in>"
+	}
+	return token.File.Path() + ":" + strconv.Itoa(token.Row) + ":" + strconv.Itoa(token.Column)
+}
+
+// Returns report of dropped declarations.
+// Each line represents single declaration.
+func get_dce_report(pkg *sema.Package, used []*sema.ImportInfo) string {
+	report := ""
+	push := func(kind string, ident string, token lex.Token) {
+		report += "dropped " + kind + ": " + ident + " (" + get_decl_pos(token) + ")\n"
+	}
+
+	push_fn := func(f *sema.Fn) {
+		for _, ins := range f.Instances {
+			if !is_reachable_fn(ins) {
+				push("function", get_fn_ins_report_ident(ins), f.Token)
+			}
+		}
+	}
+
+	push_pkg := func(p *sema.Package) {
+		for _, file := range p.Files {
+			for _, f := range file.Funcs {
+				if !f.Cpp_linked && f.Token.Id != lex.ID_NA {
+					push_fn(f)
+				}
+			}
+
+			for _, s := range file.Structs {
+				if s.Cpp_linked {
+					continue
+				}
+
+				for _, ins := range s.Instances {
+					if !is_reachable_struct(ins) {
+						push("struct", ins.To_str(), s.Token)
+						continue
+					}
+
+					for _, m := range ins.Methods {
+						push_fn(m)
+					}
+				}
+			}
+
+			for _, t := range file.Traits {
+				if !is_reachable_trait(t) {
+					push("trait", t.Ident, t.Token)
+				}
+			}
+
+			for _, v := range file.Vars {
+				if !v.Cpp_linked && !v.Constant && !lex.Is_ignore_ident(v.Ident) && !is_reachable_global(v) {
+					push("global", v.Ident, v.Token)
+				}
+			}
+		}
+	}
+
+	for _, u := range used {
+		if !u.Cpp_linked {
+			push_pkg(u.Package)
+		}
+	}
+	push_pkg(pkg)
+
+	return report
+}
@@ -0,0 +1,193 @@
+package cxx
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/sema"
+	"github.com/julelang/jule/types"
+)
+
+// C types of primitive types for exported functions.
+var C_PRIM_TYPES = map[string]string{
+	types.TypeKind_I8:      "int8_t",
+	types.TypeKind_I16:     "int16_t",
+	types.TypeKind_I32:     "int32_t",
+	types.TypeKind_I64:     "int64_t",
+	types.TypeKind_U8:      "uint8_t",
+	types.TypeKind_U16:     "uint16_t",
+	types.TypeKind_U32:     "uint32_t",
+	types.TypeKind_U64:     "uint64_t",
+	types.TypeKind_INT:     "intptr_t",
+	types.TypeKind_UINT:    "uintptr_t",
+	types.TypeKind_UINTPTR: "uintptr_t",
+	types.TypeKind_F32:     "float",
+	types.TypeKind_F64:     "double",
+	types.TypeKind_BOOL:    "bool",
+}
+
+// Returns C type of type kind for exported functions.
+// Type kind should be checked by semantic analysis.
+func gen_c_type_kind(k *sema.TypeKind) string {
+	switch {
+	case k == nil || k.Is_void():
+		return "void"
+
+	case k.Prim() != nil:
+		return C_PRIM_TYPES[k.Prim().To_str()]
+
+	case k.Ptr() != nil:
+		ptr := k.Ptr()
+		if ptr.Is_unsafe() {
+			return "void*"
+		}
+		return gen_c_type_kind(ptr.Elem) + "*"
+
+	default:
+		return "void"
+	}
+}
+
+// Returns parameter identifier of exported function wrapper.
+func export_param_ident(i int) string { return "_" + strconv.Itoa(i) }
+
+// Generates C declaration head of exported function.
+func gen_export_decl_head(f *sema.FnIns) string {
+	obj := gen_c_type_kind(f.Result) + " "
+	obj += f.Decl.Export_ident()
+	obj += "("
+	if len(f.Params) == 0 {
+		obj += "void"
+	}
+	for i, p := range f.Params {
+		if i > 0 {
+			obj += ", "
+		}
+		obj += gen_c_type_kind(p.Kind) + " " + export_param_ident(i)
+	}
+	obj += ")"
+	return obj
+}
+
+// Generates C++ code of extern "C" wrapper of exported function.
+func gen_export_wrapper(f *sema.FnIns) string {
+	call := fn_ins_out_ident(f) + "("
+	for i, p := range f.Params {
+		if i > 0 {
+			call += ", "
+		}
+		call += "(" + gen_type_kind(p.Kind) + ")(" + export_param_ident(i) + ")"
+	}
+	call += ")"
+
+	obj := "extern \"C\" " + gen_export_decl_head(f) + " {\n"
+	if f.Result == nil || f.Result.Is_void() {
+		obj += indent() + "\t" + call + CPP_ST_TERM + "\n"
+	} else {
+		obj += indent() + "\treturn (" + gen_c_type_kind(f.Result) + ")(" + call + ")" + CPP_ST_TERM + "\n"
+	}
+	obj += "}"
+	return obj
+}
+
+// Returns all exported function instances of packages.
+func get_exports(pkg *sema.Package, used []*sema.ImportInfo) []*sema.FnIns {
+	var exports []*sema.FnIns
+	push := func(p *sema.Package) {
+		for _, file := range p.Files {
+			for _, f := range file.Funcs {
+				if f.Cpp_linked || f.Token.Id == lex.ID_NA || f.Export_ident() == "" {
+					continue
+				}
+				exports = append(exports, f.Instances...)
+			}
+		}
+	}
+
+	for _, u := range used {
+		if !u.Cpp_linked {
+			push(u.Package)
+		}
+	}
+	push(pkg)
+	return exports
+}
+
+// Generates C++ code of all exported function wrappers.
+func gen_exports(exports []*sema.FnIns) string {
+	if len(exports) == 0 {
+		return ""
+	}
+
+	obj := "#include <stdint.h>\n\n"
+	for _, f := range exports {
+		obj += gen_export_wrapper(f) + "\n\n"
+	}
+	return obj
+}
+
+// Generates C header of exported functions.
+func gen_export_header(exports []*sema.FnIns, guard string) string {
+	var sb strings.Builder
+	sb.WriteString("// Auto generated by JuleC.\n\n")
+	sb.WriteString("#ifndef " + guard + "\n")
+	sb.WriteString("#define " + guard + "\n\n")
+	sb.WriteString("#include <stdint.h>\n")
+	sb.WriteString("#include <stdbool.h>\n\n")
+	sb.WriteString("#ifdef __cplusplus\n")
+	sb.WriteString("extern \"C\" {\n")
+	sb.WriteString("#endif\n\n")
+	if is_lib_buildmode() {
+		sb.WriteString("// Initializes Jule runtime and packages.\n")
+		sb.WriteString("// Must be called before any exported function.\n")
+		sb.WriteString("void " + LIB_INIT_IDENT + "(void)" + CPP_ST_TERM + "\n\n")
+	}
+	for _, f := range exports {
+		sb.WriteString(gen_export_decl_head(f))
+		sb.WriteString(CPP_ST_TERM + "\n")
+	}
+	sb.WriteString("\n#ifdef __cplusplus\n")
+	sb.WriteString("}\n")
+	sb.WriteString("#endif\n\n")
+	sb.WriteString("#endif // ifndef " + guard + "\n")
+	return sb.String()
+}
+
+// Returns path of C header of exported functions.
+func get_export_header_path() string {
+	path := get_compile_path()
+	offset := strings.LastIndex(path, ".")
+	if offset > strings.LastIndexAny(path, `/\`) {
+		path = path[:offset]
+	}
+	return path + ".h"
+}
+
+// Returns header guard of C header of exported functions.
+// Guard is in JULE_EXPORT_<NAME>_H form, name is the file name without extension.
+func get_export_header_guard() string {
+	name := filepath.Base(get_export_header_path())
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	guard := "JULE_EXPORT_"
+	for _, r := range name {
+		if r != '_' && !lex.Is_letter(r) && (r > 0xFF || !lex.Is_decimal(byte(r))) {
+			r = '_'
+		}
+		guard += strings.ToUpper(string(r))
+	}
+	return guard + "_H"
+}
+
+// Writes C header of exported functions if exist any exported function.
+// Always writes header for library build modes.
+func write_export_header(pkg *sema.Package, used []*sema.ImportInfo) {
+	exports := get_exports(pkg, used)
+	if len(exports) == 0 && !is_lib_buildmode() {
+		return
+	}
+
+	header := gen_export_header(exports, get_export_header_guard())
+	write_output(get_export_header_path(), header)
+}
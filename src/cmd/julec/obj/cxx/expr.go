@@ -641,6 +641,44 @@ func gen_explicit_deref_expr_model(m *sema.ExplicitDerefExprModel) string {
 	return obj
 }
 
+// Casts expression to type for C function pointer boundary.
+// Pointers are reinterpreted, other types are converted.
+func gen_c_fnptr_cast(k *sema.TypeKind, t string, expr string) string {
+	if k.Ptr() != nil {
+		return "reinterpret_cast<" + t + ">(" + expr + ")"
+	}
+	return "static_cast<" + t + ">(" + expr + ")"
+}
+
+// Generates non-capturing lambda which calls function.
+// Lambda decays to raw function pointer with C types.
+func gen_c_fnptr_expr_model(m *sema.CFnptrExprModel) string {
+	obj := "+[]("
+	args := ""
+	for i, p := range m.Kind.Params {
+		ident := "_" + strconv.Itoa(i)
+		if i > 0 {
+			obj += ","
+			args += ","
+		}
+		obj += gen_c_fnptr_type_kind(p.Kind) + " " + ident
+		args += gen_c_fnptr_cast(p.Kind, gen_type_kind(p.Kind), ident)
+	}
+	obj += ") -> "
+	obj += gen_c_fnptr_type_kind(m.Kind.Result)
+	obj += " { "
+
+	call := gen_fn_ins_expr_model(m.Func) + "(" + args + ")"
+	if m.Kind.Result == nil || m.Kind.Result.Is_void() {
+		obj += call
+	} else {
+		obj += "return "
+		obj += gen_c_fnptr_cast(m.Kind.Result, gen_c_fnptr_type_kind(m.Kind.Result), call)
+	}
+	obj += "; }"
+	return obj
+}
+
 func gen_expr_model(m sema.ExprModel) string {
 	switch m.(type) {
 	case *sema.TypeKind:
@@ -754,6 +792,9 @@ func gen_expr_model(m sema.ExprModel) string {
 	case *sema.ExplicitDerefExprModel:
 		return gen_explicit_deref_expr_model(m.(*sema.ExplicitDerefExprModel))
 
+	case *sema.CFnptrExprModel:
+		return gen_c_fnptr_expr_model(m.(*sema.CFnptrExprModel))
+
 	default:
 		return "<unimplemented_expression_model>"
 	}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package cxx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/julelang/jule/build"
+)
+
+// Maximum count of fix passes.
+// Fixes may cause new fixable logs, such as unused variables after removal of variables.
+const _FIX_PASSES = 8
+
+// Returns fixes of logs by path of source files.
+// Only source files of package are fixed, fixes of other packages are ignored.
+func get_fixes(path string, logs []build.Log) map[string][][]build.Edit {
+	dir, _ := filepath.Abs(path)
+	fixes := map[string][][]build.Edit{}
+	for _, l := range logs {
+		if len(l.Edits) == 0 {
+			continue
+		}
+		file_dir, _ := filepath.Abs(filepath.Dir(l.Path))
+		if file_dir == dir {
+			fixes[l.Path] = append(fixes[l.Path], l.Edits)
+		}
+	}
+	return fixes
+}
+
+// Applies fixes of logs to source files of package.
+// Returns previous contents of fixed files by path,
+// and count of applied fixes by path.
+func apply_fixes(path string, logs []build.Log) (map[string]string, map[string]int) {
+	sources := map[string]string{}
+	counts := map[string]int{}
+	for file, fixes := range get_fixes(path, logs) {
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			exit_err(err.Error())
+		}
+
+		text, n := build.Apply_fixes(string(bytes), fixes)
+		if n == 0 {
+			continue
+		}
+		write_output(file, text)
+		sources[file] = string(bytes)
+		counts[file] = n
+	}
+	return sources, counts
+}
+
+// Reports whether logs have errors which are not exist in previous logs.
+// Positions are not compared because fixes moves code.
+// Unused definitions are not new errors because removal of uses may cause them.
+func has_new_errors(prev []build.Log, logs []build.Log) bool {
+logs:
+	for _, l := range logs {
+		if !l.Is_err() || l.Class == build.WARN_UNUSED {
+			continue
+		}
+		for _, pl := range prev {
+			if pl.Is_err() && pl.Code == l.Code && pl.Path == l.Path && pl.Text == l.Text {
+				continue logs
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Analyzes package and applies fixes of logs to source files of package.
+// Package is re-checked after each pass of fixes,
+// and fixes of pass are reverted if re-check reports new errors.
+// Prints fixed files and remaining logs.
+func Fix(path string) {
+	_, _, logs := check(path)
+
+	fixed := map[string]int{}
+	for pass := 0; pass < _FIX_PASSES; pass++ {
+		sources, counts := apply_fixes(path, logs)
+		if len(counts) == 0 {
+			break
+		}
+
+		_, _, new_logs := check(path)
+		if has_new_errors(logs, new_logs) {
+			for file, text := range sources {
+				write_output(file, text)
+			}
+			println("fixes reverted: re-check reports new errors")
+			break
+		}
+
+		for file, n := range counts {
+			fixed[file] += n
+		}
+		logs = new_logs
+	}
+
+	if DIAG_FORMAT != build.DIAG_FORMAT_JSON {
+		files := make([]string, 0, len(fixed))
+		for file := range fixed {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			println("fixed:", file, "("+strconv.Itoa(fixed[file])+")")
+		}
+	}
+
+	print_logs(logs)
+}
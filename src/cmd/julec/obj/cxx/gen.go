@@ -2,6 +2,7 @@ package cxx
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -98,8 +99,15 @@ func get_all_variables(pkg *sema.Package, used []*sema.ImportInfo) []*sema.Var {
 }
 
 // Generates all C/C++ include directives.
+// Inlines API and local headers instead of include if amalgamation enabled.
 func gen_links(used []*sema.ImportInfo) string {
 	obj := ""
+	var a *_Amalgamator = nil
+	if AMALGAMATE {
+		a = new_amalgamator()
+		obj += a.header(get_api_path()) + "\n"
+	}
+
 	for _, pkg := range used {
 		switch {
 		case !pkg.Cpp_linked:
@@ -109,7 +117,11 @@ func gen_links(used []*sema.ImportInfo) string {
 			obj += "#include " + pkg.Path + "\n"
 
 		case is_cpp_header_file(pkg.Path):
-			obj += `#include "` + pkg.Path + "\"\n"
+			if a != nil {
+				obj += a.header(pkg.Path)
+			} else {
+				obj += `#include "` + pkg.Path + "\"\n"
+			}
 		}
 	}
 	return obj
@@ -274,7 +286,9 @@ func gen_trait(t *sema.Trait) string {
 func gen_traits_tbl(tbl *sema.SymbolTable) string {
 	obj := ""
 	for _, t := range tbl.Traits {
-		obj += gen_trait(t) + "\n\n"
+		if is_reachable_trait(t) {
+			obj += gen_trait(t) + "\n\n"
+		}
 	}
 	return obj
 }
@@ -310,7 +324,7 @@ func gen_trait_prototypes(p *sema.Package) string {
 	obj := ""
 	for _, f := range p.Files {
 		for _, t := range f.Traits {
-			if t.Token.Id != lex.ID_NA {
+			if t.Token.Id != lex.ID_NA && is_reachable_trait(t) {
 				obj += gen_trait_prototype(t) + "\n"
 			}
 		}
@@ -322,6 +336,10 @@ func gen_trait_prototypes(p *sema.Package) string {
 func gen_struct_plain_prototype(s *sema.Struct) string {
 	obj := ""
 	for _, ins := range s.Instances {
+		if !is_reachable_struct(ins) {
+			continue
+		}
+
 		obj += "\nstruct "
 		obj += struct_ins_out_ident(ins)
 		obj += CPP_ST_TERM
@@ -369,8 +387,28 @@ func gen_struct_self_field(s *sema.StructIns) string {
 }
 
 // Generates C++ declaration code of field.
+// Returns C++ type of field of structure with C layout.
+// Fixed-width primitive types are C types.
+// Platform dependent integers are not lowered to keep field pointers compatible.
+func gen_repr_c_field_kind(k *sema.TypeKind) string {
+	prim := k.Prim()
+	if k.Cpp_linked || prim == nil || prim.Is_int() || prim.Is_uint() || prim.Is_uintptr() {
+		return gen_type_kind(k)
+	}
+	ck, ok := C_PRIM_TYPES[prim.To_str()]
+	if !ok {
+		return gen_type_kind(k)
+	}
+	return ck
+}
+
 func gen_field_decl(f *sema.FieldIns) string {
-	obj := gen_type_kind(f.Kind) + " "
+	obj := ""
+	if f.Decl.Owner.Is_repr_c() {
+		obj += gen_repr_c_field_kind(f.Kind) + " "
+	} else {
+		obj += gen_type_kind(f.Kind) + " "
+	}
 	obj += field_out_ident(f.Decl)
 	obj += "{" + get_init_expr(f.Kind) + "}"
 	obj += CPP_ST_TERM
@@ -398,10 +436,13 @@ func gen_struct_constructor(s *sema.StructIns) string {
 		obj += "void"
 	}
 
-	obj += ") noexcept {\n"
+	obj += ") noexcept {"
 	add_indent()
-	obj += indent()
-	obj += gen_struct_self_field_init_st(s)
+	if !s.Decl.Is_repr_c() {
+		obj += "\n"
+		obj += indent()
+		obj += gen_struct_self_field_init_st(s)
+	}
 	obj += "\n"
 
 	if len(s.Fields) > 0 {
@@ -481,17 +522,37 @@ func gen_struct_derive_defs_prototypes(s *sema.StructIns) string {
 	return obj
 }
 
+// Generates C++ attributes of structure layout.
+func gen_struct_layout_attrs(s *sema.Struct) string {
+	obj := ""
+	align := s.Align()
+	if align > 0 {
+		obj += "alignas(" + strconv.FormatUint(align, 10) + ") "
+	}
+	if s.Is_packed() {
+		obj += "__attribute__((packed)) "
+	}
+	return obj
+}
+
+// Generates C++ declaration code of structure instance.
+// Structures with C layout have not self field because
+// C layout allows declared fields only.
 func gen_struct_ins_prototype(s *sema.StructIns) string {
+	repr_c := s.Decl.Is_repr_c()
 	obj := "struct "
+	obj += gen_struct_layout_attrs(s.Decl)
 	out_ident := struct_ins_out_ident(s)
 	obj += out_ident
 	obj += gen_struct_traits(s.Decl)
 	obj += " {\n"
 
 	add_indent()
-	obj += indent()
-	obj += gen_struct_self_field(s)
-	obj += "\n\n"
+	if !repr_c {
+		obj += indent()
+		obj += gen_struct_self_field(s)
+		obj += "\n\n"
+	}
 	if len(s.Fields) > 0 {
 		for _, f := range s.Fields {
 			obj += indent()
@@ -504,15 +565,21 @@ func gen_struct_ins_prototype(s *sema.StructIns) string {
 		obj += "\n\n"
 	}
 
-	obj += indent()
-	obj += gen_struct_destructor(s)
-	obj += "\n\n"
+	if repr_c {
+		obj += indent()
+		obj += out_ident
+		obj += "(void) noexcept {}\n\n"
+	} else {
+		obj += indent()
+		obj += gen_struct_destructor(s)
+		obj += "\n\n"
 
-	obj += indent()
-	obj += out_ident
-	obj += "(void) noexcept { "
-	obj += gen_struct_self_field_init_st(s)
-	obj += " }\n\n"
+		obj += indent()
+		obj += out_ident
+		obj += "(void) noexcept { "
+		obj += gen_struct_self_field_init_st(s)
+		obj += " }\n\n"
+	}
 
 	for _, f := range s.Methods {
 		obj += gen_fn_prototype(f, true)
@@ -533,7 +600,9 @@ func gen_struct_ins_prototype(s *sema.StructIns) string {
 func gen_struct_prototype(s *sema.Struct) string {
 	obj := ""
 	for _, ins := range s.Instances {
-		obj += gen_struct_ins_prototype(ins) + "\n\n"
+		if is_reachable_struct(ins) {
+			obj += gen_struct_ins_prototype(ins) + "\n\n"
+		}
 	}
 	return obj
 }
@@ -549,8 +618,45 @@ func gen_struct_prototypes(structs []*sema.Struct) string {
 	return obj
 }
 
-func gen_fn_decl_head(f *sema.FnIns, method bool) string {
+// Returns attribute of function attribute directive for compiler.
+// GCC and Clang accepts same GNU attributes except hot attribute,
+// which is not supported by Clang versions older than 12.
+func get_fn_attribute(tag string) string {
+	switch tag {
+	case build.DIRECTIVE_INLINE:
+		return "__attribute__((always_inline))"
+
+	case build.DIRECTIVE_NOINLINE:
+		return "__attribute__((noinline))"
+
+	case build.DIRECTIVE_COLD:
+		return "__attribute__((cold))"
+
+	case build.DIRECTIVE_HOT:
+		if COMPILER == COMPILER_CLANG {
+			return "__JULE_ATTRIBUTE_HOT"
+		}
+		return "__attribute__((hot))"
+
+	default:
+		return ""
+	}
+}
+
+// Generates C++ attributes of function attribute directives.
+func gen_fn_attributes(f *sema.Fn) string {
 	obj := ""
+	for _, d := range f.Directives {
+		attr := get_fn_attribute(d.Tag)
+		if attr != "" {
+			obj += attr + " "
+		}
+	}
+	return obj
+}
+
+func gen_fn_decl_head(f *sema.FnIns, method bool) string {
+	obj := gen_fn_attributes(f.Decl)
 	if !f.Decl.Is_entry_point() {
 		obj += "inline "
 	}
@@ -568,6 +674,10 @@ func gen_fn_decl_head(f *sema.FnIns, method bool) string {
 func gen_fn_prototype(f *sema.Fn, method bool) string {
 	obj := ""
 	for _, c := range f.Instances {
+		if !is_reachable_fn(c) {
+			continue
+		}
+
 		obj += indent()
 		obj += gen_fn_decl_head(c, method)
 		obj += gen_params_prototypes(c.Params)
@@ -626,7 +736,11 @@ func gen_var(v *sema.Var) string {
 		return ""
 	}
 
-	obj := gen_type_kind(v.Kind.Kind) + " "
+	obj := ""
+	if v.Is_thread_local() {
+		obj += "thread_local "
+	}
+	obj += gen_type_kind(v.Kind.Kind) + " "
 	obj += var_out_ident(v)
 	if v.Value != nil && v.Value.Expr != nil {
 		if v.Value.Data.Model != nil {
@@ -647,7 +761,7 @@ func gen_globals(globals []*sema.Var) string {
 	obj := ""
 
 	for _, v := range globals {
-		if !v.Constant && v.Token.Id != lex.ID_NA {
+		if !v.Constant && v.Token.Id != lex.ID_NA && is_reachable_global(v) {
 			obj += gen_var(v) + "\n"
 		}
 	}
@@ -659,6 +773,10 @@ func gen_globals(globals []*sema.Var) string {
 func gen_fn(f *sema.Fn) string {
 	obj := ""
 	for _, c := range f.Instances {
+		if !is_reachable_fn(c) {
+			continue
+		}
+
 		obj += gen_fn_decl_head(c, false)
 		obj += gen_params_ins(c.Params) + " "
 		obj += gen_fn_scope(c)
@@ -771,7 +889,9 @@ func gen_struct_ins(s *sema.StructIns) string {
 func gen_struct(s *sema.Struct) string {
 	obj := ""
 	for _, ins := range s.Instances {
-		obj += gen_struct_ins(ins) + "\n\n"
+		if is_reachable_struct(ins) {
+			obj += gen_struct_ins(ins) + "\n\n"
+		}
 	}
 	return obj
 }
@@ -831,7 +951,7 @@ func gen_init_caller(pkg *sema.Package, used []*sema.ImportInfo) string {
 	return obj
 }
 
-func append_standard(obj_code *string, compiler string, compiler_cmd string) {
+func append_standard(obj_code *string, cmds []string) {
 	y, m, d := time.Now().Date()
 	h, min, _ := time.Now().Clock()
 	timeStr := fmt.Sprintf("%d/%d/%d %d.%d (DD/MM/YYYY) (HH.MM)", d, m, y, h, min)
@@ -844,25 +964,19 @@ func append_standard(obj_code *string, compiler string, compiler_cmd string) {
 	sb.WriteString(timeStr)
 	sb.WriteString(`
 //
-// Recommended Compile Command;
-// `)
-	sb.WriteString(compiler)
-	sb.WriteByte(' ')
-	sb.WriteString(compiler_cmd)
-	sb.WriteString("\n\n#include \"")
-	sb.WriteString(build.PATH_API)
-	sb.WriteString("\"\n\n")
+// Recommended Compile Command;`)
+	for _, cmd := range cmds {
+		sb.WriteString("\n// ")
+		sb.WriteString(get_display_cmd(cmd))
+	}
+	sb.WriteString("\n\n")
+	if !AMALGAMATE {
+		sb.WriteString("#include \"")
+		sb.WriteString(get_api_path())
+		sb.WriteString("\"\n\n")
+	}
 	sb.WriteString(*obj_code)
-	sb.WriteString(`
-int main(int argc, char *argv[]) {
-	std::set_terminate(&jule::terminate_handler);
-	jule::set_sig_handler(jule::signal_handler);
-	jule::setup_command_line_args(argc, argv);
-	__jule_call_initializers();
-	entry_point();
-
-	return EXIT_SUCCESS;
-}`)
+	sb.WriteString(gen_entry())
 	*obj_code = sb.String()
 }
 
@@ -875,12 +989,18 @@ func Gen(pkg *sema.Package, used []*sema.ImportInfo) string {
 	od.globals = get_all_variables(pkg, used)
 	order_variables(od.globals)
 
+	REACHABLE = nil
+	if DCE {
+		REACHABLE = analyze_reachability(pkg, used)
+	}
+
 	obj := ""
 	obj += gen_links(used) + "\n"
 	obj += gen_prototypes(pkg, used, od.structs) + "\n\n"
 	obj += gen_globals(od.globals) + "\n"
 	obj += gen_structs(od.structs)
 	obj += gen_fns(pkg, used) + "\n"
+	obj += gen_exports(get_exports(pkg, used))
 	obj += gen_init_caller(pkg, used) + "\n"
 	return obj
 }
@@ -125,6 +125,10 @@ func struct_out_ident(s *sema.Struct) string {
 
 // Returns output identifier of structure instance.
 func struct_ins_out_ident(s *sema.StructIns) string {
+	if s.Decl.Cpp_linked && len(s.Generics) > 0 {
+		return struct_out_ident(s.Decl) + gen_cpp_generics(s.Generics)
+	}
+
 	if s.Decl.Cpp_linked || len(s.Generics) == 0 {
 		return struct_out_ident(s.Decl)
 	}
@@ -0,0 +1,233 @@
+package cxx
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/sema"
+)
+
+// Program of pkg-config.
+const PKG_CONFIG = "pkg-config"
+
+// File name of link manifest in main package directory.
+// Each line maps library or linked source file to flags in "key: flags" form.
+// Keys with C++ source extension are source files relative to manifest,
+// others are libraries. Empty lines and lines starts with hash are ignored.
+const LINK_MANIFEST = "jule.link"
+
+// Path of link manifest.
+// Uses LINK_MANIFEST of main package directory if empty.
+// Sets by command-line inputs.
+var LINK_MANIFEST_PATH = ""
+
+// Flags which take argument as separate flag.
+var FLAGS_WITH_ARG = [...]string{
+	"-framework",
+	"-Xlinker",
+	"-include",
+	"-isystem",
+	"-iquote",
+	"-idirafter",
+	"-I",
+	"-L",
+	"-l",
+	"-D",
+	"-U",
+}
+
+// Flags of link manifest.
+type _Manifest struct {
+	libs     map[string][]string
+	sources  map[string][]string // Keys are absolute paths.
+	resolved map[string][]string // Resolved flags of libraries, avoids calling pkg-config again.
+}
+
+// Returns all unique libraries of jule:link directives.
+// Libraries are ordered by dependents first.
+func get_all_unique_links(pkg *sema.Package, uses []*sema.ImportInfo) []string {
+	var links []string
+	push_links := func(p *sema.Package) {
+		for _, f := range p.Files {
+		push:
+			for _, link := range f.Links {
+				for _, clink := range links {
+					if clink == link.Lib {
+						continue push
+					}
+				}
+
+				links = append(links, link.Lib)
+			}
+		}
+	}
+
+	push_links(pkg)
+	for _, u := range uses {
+		if !u.Cpp_linked {
+			push_links(u.Package)
+		}
+	}
+
+	return links
+}
+
+// Returns path of link manifest.
+// Path is the path of main package.
+func get_link_manifest_path(path string) string {
+	if LINK_MANIFEST_PATH != "" {
+		return LINK_MANIFEST_PATH
+	}
+	return filepath.Join(path, LINK_MANIFEST)
+}
+
+// Reads link manifest.
+// Returns empty manifest if manifest is not exist.
+func read_link_manifest(path string) *_Manifest {
+	manifest := &_Manifest{
+		libs:     map[string][]string{},
+		sources:  map[string][]string{},
+		resolved: map[string][]string{},
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if LINK_MANIFEST_PATH != "" {
+			exit_err("link manifest cannot read: " + path)
+		}
+		return manifest
+	}
+
+	dir, _ := filepath.Abs(filepath.Dir(path))
+	for i, line := range strings.Split(string(bytes), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		key, flags, ok := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			exit_err("invalid link manifest line: " + path + ":" + strconv.Itoa(i+1))
+		}
+
+		if is_cpp_source_file(key) {
+			if !filepath.IsAbs(key) {
+				key = filepath.Join(dir, key)
+			}
+			manifest.sources[key] = strings.Fields(flags)
+		} else {
+			manifest.libs[key] = strings.Fields(flags)
+		}
+	}
+	return manifest
+}
+
+// Returns flags of library by pkg-config.
+// Reports whether pkg-config is available and knows library.
+func pkg_config(lib string) ([]string, bool) {
+	out, err := exec.Command(PKG_CONFIG, "--cflags", "--libs", lib).Output()
+	if err != nil {
+		return nil, false
+	}
+	return strings.Fields(string(out)), true
+}
+
+// Returns flags of library.
+// Resolves by pkg-config first, link manifest if pkg-config fails.
+// Links by library name if library is not exist in manifest.
+func resolve_link(lib string, manifest *_Manifest) []string {
+	flags, ok := manifest.resolved[lib]
+	if ok {
+		return flags
+	}
+
+	flags, ok = pkg_config(lib)
+	if !ok {
+		flags, ok = manifest.libs[lib]
+		if !ok {
+			flags = []string{"-l" + lib}
+		}
+	}
+	manifest.resolved[lib] = flags
+	return flags
+}
+
+// Returns flags of all libraries of jule:link directives.
+func resolve_links(manifest *_Manifest, pkg *sema.Package, uses []*sema.ImportInfo) []string {
+	links := get_all_unique_links(pkg, uses)
+	var flags []string
+	for _, lib := range links {
+		flags = append(flags, resolve_link(lib, manifest)...)
+	}
+	return flags
+}
+
+// Reports whether flag takes argument as separate flag.
+func is_flag_with_arg(flag string) bool {
+	for _, f := range FLAGS_WITH_ARG {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// Groups flags with their separate arguments, such as "-framework Cocoa".
+// Grouped flags are handled as one flag.
+func group_flags(flags []string) []string {
+	var grouped []string
+	for i := 0; i < len(flags); i++ {
+		flag := flags[i]
+		if is_flag_with_arg(flag) && i+1 < len(flags) {
+			i++
+			flag += " " + flags[i]
+		}
+		grouped = append(grouped, flag)
+	}
+	return grouped
+}
+
+// Removes duplicated flags.
+// Flags should be grouped with their arguments.
+// Libraries keeps last occurrence because libraries must be
+// placed after dependent libraries, other flags keeps first occurrence.
+// Linker arguments are kept because meaning of them depends on neighbors.
+func dedup_flags(flags []string) []string {
+	var unique []string
+	for i, flag := range flags {
+		if strings.HasPrefix(flag, "-Xlinker ") {
+			unique = append(unique, flag)
+			continue
+		}
+
+		if strings.HasPrefix(flag, "-l") {
+			last := true
+			for _, next := range flags[i+1:] {
+				if next == flag {
+					last = false
+					break
+				}
+			}
+			if last {
+				unique = append(unique, flag)
+			}
+			continue
+		}
+
+		exist := false
+		for _, uflag := range unique {
+			if uflag == flag {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			unique = append(unique, flag)
+		}
+	}
+	return unique
+}
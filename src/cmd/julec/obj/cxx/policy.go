@@ -0,0 +1,325 @@
+package cxx
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/sema"
+)
+
+const PASS_POLICY_PERMISSIVE = "permissive"
+const PASS_POLICY_STRICT = "strict"
+
+// Policy of jule:pass directives, jule:link directives and cpp use declarations.
+// Permissive policy accepts everything.
+// Strict policy denies passes and resolved flags of links that not matches
+// with PASS_ALLOWLIST and cpp use declarations out of package directory
+// for untrusted packages.
+// Sets by command-line inputs.
+var PASS_POLICY = PASS_POLICY_PERMISSIVE
+
+// Trust levels of packages.
+const TRUST_STD = "std"       // Standard library packages.
+const TRUST_MAIN = "main"     // Packages of main module.
+const TRUST_VENDOR = "vendor" // Vendored packages and packages out of main module.
+
+// Directory name of vendored packages in main module.
+const VENDOR_DIR = "vendor"
+
+// Trusted levels for strict policy.
+// Packages of trusted levels are not checked by policy.
+// Sets by command-line inputs.
+var TRUSTED = []string{TRUST_STD, TRUST_MAIN}
+
+// Allowed flag patterns of passes for strict policy.
+// Pattern matches with prefix if ends with asterisk, exactly otherwise.
+// Command-line inputs appends new patterns.
+var PASS_ALLOWLIST = []string{
+	"-l*",
+	"-D*",
+	"-U*",
+	"-O*",
+	"-std=*",
+	"--std=*",
+	"-pthread",
+}
+
+// Prints audit report of passes, links and cpp use declarations if true.
+var PRINT_PASSES = false
+
+// Verdicts of policy audit.
+const _VERDICT_TRUSTED = "trusted"
+const _VERDICT_ALLOWED = "allowed"
+const _VERDICT_DENIED = "denied"
+
+// Kinds of audit records.
+const _RECORD_PASS = "pass"
+const _RECORD_LINK = "link"
+const _RECORD_USE = "use cpp"
+
+// Reports whether trust level is trusted.
+func is_trusted(level string) bool {
+	for _, trusted := range TRUSTED {
+		if trusted == level {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether flag matches with pattern.
+func match_pass_pattern(pattern string, flag string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(flag, pattern[:len(pattern)-1])
+	}
+	return pattern == flag
+}
+
+// Reports whether flag is allowed by PASS_ALLOWLIST.
+func is_allowed_pass_flag(flag string) bool {
+	for _, pattern := range PASS_ALLOWLIST {
+		if match_pass_pattern(pattern, flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether path is inside of directory.
+func is_inside_dir(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Returns trust level of package.
+// Root is the absolute path of main package.
+func get_trust_level(root string, path string, std bool) string {
+	if std {
+		return TRUST_STD
+	}
+
+	if !is_inside_dir(root, path) {
+		return TRUST_VENDOR
+	}
+
+	rel, _ := filepath.Rel(root, path)
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == VENDOR_DIR {
+			return TRUST_VENDOR
+		}
+	}
+	return TRUST_MAIN
+}
+
+// Audit record of pass, link or cpp use declaration.
+type _AuditRecord struct {
+	token   lex.Token
+	pkg     string // Link path of package.
+	level   string // Trust level of package.
+	kind    string
+	lib     string // Library of link.
+	text    string // Pass flags, resolved flags of link or link path and flags of cpp use declaration.
+	verdict string
+	denied  string // Denied flag.
+}
+
+// Returns verdict of pass for trust level.
+// Returns denied flag as second result if pass is denied.
+func get_pass_verdict(pass string, level string) (string, string) {
+	if is_trusted(level) {
+		return _VERDICT_TRUSTED, ""
+	}
+
+	for _, flag := range strings.Fields(pass) {
+		if !is_allowed_pass_flag(flag) {
+			return _VERDICT_DENIED, flag
+		}
+	}
+	return _VERDICT_ALLOWED, ""
+}
+
+// Returns verdict of cpp use declaration for trust level.
+// Cpp use declarations of untrusted packages must be standard headers
+// or inside of package directory, and compiler flags must be allowed.
+// Returns denied flag as second result if flags are denied.
+func get_use_verdict(imp *sema.ImportInfo, dir string, level string) (string, string) {
+	switch {
+	case is_trusted(level):
+		return _VERDICT_TRUSTED, ""
+
+	case !build.Is_std_header_path(imp.Path) && !is_inside_dir(dir, imp.Path):
+		return _VERDICT_DENIED, ""
+	}
+
+	for _, flag := range strings.Fields(imp.Cpp_flags) {
+		if !is_allowed_pass_flag(flag) {
+			return _VERDICT_DENIED, flag
+		}
+	}
+	return _VERDICT_ALLOWED, ""
+}
+
+// Returns audit records of package.
+// Links are audited by resolved flags.
+func audit_package(p *sema.Package, pkg string, dir string, level string, manifest *_Manifest) []*_AuditRecord {
+	var records []*_AuditRecord
+	for _, f := range p.Files {
+		for _, pass := range f.Passes {
+			if pass.Text == "" {
+				continue
+			}
+
+			verdict, denied := get_pass_verdict(pass.Text, level)
+			records = append(records, &_AuditRecord{
+				token:   pass.Token,
+				pkg:     pkg,
+				level:   level,
+				kind:    _RECORD_PASS,
+				text:    pass.Text,
+				verdict: verdict,
+				denied:  denied,
+			})
+		}
+
+		for _, link := range f.Links {
+			flags := strings.Join(resolve_link(link.Lib, manifest), " ")
+			verdict, denied := get_pass_verdict(flags, level)
+			records = append(records, &_AuditRecord{
+				token:   link.Token,
+				pkg:     pkg,
+				level:   level,
+				kind:    _RECORD_LINK,
+				lib:     link.Lib,
+				text:    flags,
+				verdict: verdict,
+				denied:  denied,
+			})
+		}
+
+		for _, imp := range f.Imports {
+			if !imp.Cpp_linked {
+				continue
+			}
+
+			text := imp.Link_path
+			if imp.Cpp_flags != "" {
+				text += " " + imp.Cpp_flags
+			}
+
+			verdict, denied := get_use_verdict(imp, dir, level)
+			records = append(records, &_AuditRecord{
+				token:   imp.Token,
+				pkg:     pkg,
+				level:   level,
+				kind:    _RECORD_USE,
+				text:    text,
+				verdict: verdict,
+				denied:  denied,
+			})
+		}
+	}
+	return records
+}
+
+// Returns audit records of all packages.
+// Path is the path of main package.
+func audit(path string, pkg *sema.Package, used []*sema.ImportInfo, manifest *_Manifest) []*_AuditRecord {
+	root, err := filepath.Abs(path)
+	if err != nil {
+		root = path
+	}
+
+	records := audit_package(pkg, "main", root, TRUST_MAIN, manifest)
+	for _, u := range used {
+		if u.Cpp_linked {
+			continue
+		}
+		level := get_trust_level(root, u.Path, u.Std)
+		records = append(records, audit_package(u.Package, u.Link_path, u.Path, level, manifest)...)
+	}
+	return records
+}
+
+// Returns errors of denied audit records.
+func get_policy_errors(records []*_AuditRecord) []build.Log {
+	var errors []build.Log
+	for _, r := range records {
+		if r.verdict != _VERDICT_DENIED {
+			continue
+		}
+
+		log := build.Log{
+			Type:   build.ERR,
+			Row:    r.token.Row,
+			Column: r.token.Column,
+			Path:   r.token.File.Path(),
+		}
+		switch {
+		case r.kind == _RECORD_LINK:
+			log.Text = build.Errorf("link_flag_denied_by_policy", r.denied, r.lib, r.pkg)
+			log.Code = build.Code("link_flag_denied_by_policy")
+
+		case r.kind == _RECORD_USE && r.denied != "":
+			log.Text = build.Errorf("cpp_flag_denied_by_policy", r.denied, r.pkg)
+			log.Code = build.Code("cpp_flag_denied_by_policy")
+
+		case r.kind == _RECORD_USE:
+			log.Text = build.Errorf("use_cpp_denied_by_policy", r.text, r.pkg)
+			log.Code = build.Code("use_cpp_denied_by_policy")
+
+		default:
+			log.Text = build.Errorf("pass_denied_by_policy", r.denied, r.pkg)
+			log.Code = build.Code("pass_denied_by_policy")
+		}
+		errors = append(errors, log)
+	}
+	return errors
+}
+
+// Returns audit report of passes, links and cpp use declarations.
+func get_audit_report(records []*_AuditRecord) string {
+	var sb strings.Builder
+	sb.WriteString("pass policy: " + PASS_POLICY + "\n")
+	sb.WriteString("trusted levels: " + strings.Join(TRUSTED, ", ") + "\n")
+	for _, r := range records {
+		sb.WriteString("  [" + r.verdict + "] " + r.kind + " ")
+		if r.kind == _RECORD_LINK {
+			sb.WriteString(r.lib + ": ")
+		}
+		sb.WriteString(r.text)
+		sb.WriteString("\n      package: " + r.pkg + " (" + r.level + ")")
+		sb.WriteString("\n      at: " + r.token.File.Path() + ":" + strconv.Itoa(r.token.Row) + ":" + strconv.Itoa(r.token.Column))
+		if r.denied != "" && r.denied != r.text {
+			sb.WriteString("\n      denied flag: " + r.denied)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Checks passes, links and cpp use declarations by policy.
+// Prints audit report if enabled.
+// Reports whether policy accepts all packages.
+func check_policy(path string, pkg *sema.Package, used []*sema.ImportInfo, manifest *_Manifest) bool {
+	records := audit(path, pkg, used, manifest)
+	if PRINT_PASSES {
+		print(get_audit_report(records))
+	}
+
+	if PASS_POLICY != PASS_POLICY_STRICT {
+		return true
+	}
+
+	errors := get_policy_errors(records)
+	if len(errors) > 0 {
+		print_logs(errors)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,211 @@
+package cxx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/sema"
+)
+
+// Directory of cached objects of linked sources in output directory.
+const OBJ_DIR = "obj"
+
+// Length of content hash in object file names.
+const _HASH_LEN = 16
+
+// Linked C++ or Objective-C++ source file.
+// Compiled separately into cached object.
+type _Source struct {
+	path   string
+	flags  []string // Compile flags of source.
+	object string   // Object path, named by content hash.
+}
+
+// Reports whether source file is Objective-C++ source.
+func is_objective_cpp_source_file(path string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range build.OBJECTIVE_CPP_EXTS {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns compile flags of linked source.
+// Flags of manifest comes before flags of use declaration.
+func get_source_flags(u *sema.ImportInfo, manifest *_Manifest) []string {
+	var flags []string
+	if is_lib_buildmode() && BUILDMODE != BUILDMODE_OBJECT {
+		flags = append(flags, "-fPIC")
+	}
+	if is_objective_cpp_source_file(u.Path) {
+		flags = append(flags, "-x", "objective-c++")
+	}
+	flags = append(flags, manifest.sources[u.Path]...)
+	flags = append(flags, strings.Fields(u.Cpp_flags)...)
+	return flags
+}
+
+// Returns content hash of source with compiler and flags.
+// Returns empty string if source is not readable.
+// Headers are not hashed, cache is checked by dependencies of object.
+func get_source_hash(path string, flags []string) string {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.New()
+	h.Write(bytes)
+	h.Write([]byte{0})
+	h.Write([]byte(COMPILER_PATH))
+	for _, flag := range flags {
+		h.Write([]byte{0})
+		h.Write([]byte(flag))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:_HASH_LEN]
+}
+
+// Returns object path of linked source.
+func get_source_object_path(path string, hash string) string {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	dir := filepath.Join(filepath.Dir(get_compile_path()), OBJ_DIR)
+	return filepath.Join(dir, name+"-"+hash+".o")
+}
+
+// Returns linked C++ and Objective-C++ source files.
+func get_linked_sources(used []*sema.ImportInfo, manifest *_Manifest) []*_Source {
+	var sources []*_Source
+	for _, u := range used {
+		if !u.Cpp_linked || !is_cpp_source_file(u.Path) {
+			continue
+		}
+
+		flags := get_source_flags(u, manifest)
+		sources = append(sources, &_Source{
+			path:   u.Path,
+			flags:  flags,
+			object: get_source_object_path(u.Path, get_source_hash(u.Path, flags)),
+		})
+	}
+	return sources
+}
+
+// Returns object paths of linked sources.
+func get_source_objects(sources []*_Source) []string {
+	objects := make([]string, len(sources))
+	for i, s := range sources {
+		objects[i] = s.object
+	}
+	return objects
+}
+
+// Returns dependency file path of object.
+// Dependency file is generated by compiler with object.
+func get_source_dep_path(object string) string {
+	return strings.TrimSuffix(object, filepath.Ext(object)) + ".d"
+}
+
+// Returns dependencies of dependency file in make rule form.
+// Reports whether dependency file is readable.
+func read_source_deps(path string) ([]string, bool) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	content := strings.ReplaceAll(string(bytes), "\\\r\n", " ")
+	content = strings.ReplaceAll(content, "\\\n", " ")
+	_, content, ok := strings.Cut(content, ": ")
+	if !ok {
+		return nil, false
+	}
+
+	var deps []string
+	dep := ""
+	for i := 0; i < len(content); i++ {
+		b := content[i]
+		switch {
+		case b == '\\' && i+1 < len(content) && content[i+1] == ' ':
+			// Escaped space of path.
+			dep += " "
+			i++
+
+		case b == ' ' || b == '\t' || b == '\r' || b == '\n':
+			if dep != "" {
+				deps = append(deps, dep)
+				dep = ""
+			}
+
+		default:
+			dep += string(b)
+		}
+	}
+	if dep != "" {
+		deps = append(deps, dep)
+	}
+	return deps, true
+}
+
+// Reports whether object of source is already compiled.
+// Object is not cached if any dependency such as header is modified after object.
+func is_cached_source(s *_Source) bool {
+	info, err := os.Stat(s.object)
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	deps, ok := read_source_deps(get_source_dep_path(s.object))
+	if !ok {
+		return false
+	}
+	for _, dep := range deps {
+		dinfo, err := os.Stat(dep)
+		if err != nil || dinfo.ModTime().After(info.ModTime()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns compile command of linked source to object.
+// Compiler writes dependencies of source to check cache.
+func gen_source_cmd(s *_Source) string {
+	cmd := COMPILER_PATH + " -c "
+	for _, flag := range s.flags {
+		cmd += flag + " "
+	}
+	cmd += "-MMD -MF " + get_source_dep_path(s.object) + " "
+	cmd += "-o " + s.object + " "
+	cmd += s.path
+	return cmd
+}
+
+// Returns compile commands of linked sources that not cached.
+func gen_source_cmds(sources []*_Source) []string {
+	var cmds []string
+	for _, s := range sources {
+		if !is_cached_source(s) {
+			cmds = append(cmds, gen_source_cmd(s))
+		}
+	}
+	return cmds
+}
+
+// Creates directory of cached objects if there is linked source.
+func make_obj_dir(sources []*_Source) {
+	if len(sources) == 0 {
+		return
+	}
+
+	err := os.MkdirAll(filepath.Join(filepath.Dir(get_compile_path()), OBJ_DIR), 0o777)
+	if err != nil {
+		exit_err(err.Error())
+	}
+}
@@ -107,6 +107,24 @@ func gen_struct_kind_ins(s *sema.StructIns) string {
 	return struct_ins_out_ident(s)
 }
 
+// Generates C++ template arguments of cpp-linked generic type.
+// Returns empty string if there is no generic.
+func gen_cpp_generics(generics []*sema.TypeKind) string {
+	if len(generics) == 0 {
+		return ""
+	}
+
+	obj := "<"
+	for i, g := range generics {
+		if i > 0 {
+			obj += ", "
+		}
+		obj += gen_type_kind(g)
+	}
+	obj += ">"
+	return obj
+}
+
 // Generates C++ code of Arr TypeKind.
 func gen_array_kind(a *sema.Arr) string {
 	arr := as_jt("array")
@@ -137,10 +155,57 @@ func gen_fn_anon_decl(f *sema.FnIns) string {
 	return decl
 }
 
+// Returns C type of parameter or result of C function pointer.
+// Type kind should be checked by semantic analysis.
+func gen_c_fnptr_type_kind(k *sema.TypeKind) string {
+	switch {
+	case k == nil || k.Is_void():
+		return "void"
+
+	case k.Cpp_linked:
+		return gen_type_kind(k)
+
+	case k.Fnc() != nil:
+		return gen_fn_kind(k.Fnc())
+
+	case k.Ptr() != nil:
+		ptr := k.Ptr()
+		if ptr.Is_unsafe() {
+			return "void*"
+		}
+		return gen_c_fnptr_type_kind(ptr.Elem) + "*"
+
+	default:
+		return gen_c_type_kind(k)
+	}
+}
+
+// Generates C++ code of C function pointer type.
+// Parameters and result are lowered through C types.
+func gen_c_fnptr_kind(f *sema.FnIns) string {
+	decl := gen_c_fnptr_type_kind(f.Result) + "("
+	if len(f.Params) > 0 {
+		for i, p := range f.Params {
+			if i > 0 {
+				decl += ","
+			}
+			decl += gen_c_fnptr_type_kind(p.Kind)
+		}
+	} else {
+		decl += "void"
+	}
+	decl += ")"
+	return "std::add_pointer<" + decl + ">::type"
+}
+
 // Generates C++ code of Fn TypeKind.
+// C function pointers are raw function pointers.
 func gen_fn_kind(f *sema.FnIns) string {
-	fnc := as_jt("fn")
+	if f.Decl.C_fnptr {
+		return gen_c_fnptr_kind(f)
+	}
 	decl := gen_fn_anon_decl(f)
+	fnc := as_jt("fn")
 	return fnc + "<" + decl + ">"
 }
 
@@ -148,7 +213,7 @@ func gen_fn_kind(f *sema.FnIns) string {
 func gen_type_kind(k *sema.TypeKind) string {
 	switch {
 	case k.Cpp_linked:
-		return k.Cpp_ident
+		return k.Cpp_ident + gen_cpp_generics(k.Cpp_generics)
 
 	case k.Prim() != nil:
 		return gen_prim_kind(k.Prim())
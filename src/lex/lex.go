@@ -111,6 +111,7 @@ func make_err(row int, col int, f *File, key string, args ...any) build.Log {
 		Column: col,
 		Path:   f.Path(),
 		Text:   build.Errorf(key, args...),
+		Code:   build.Code(key),
 	}
 }
 
@@ -715,6 +716,16 @@ func (l *_Lex) is_kw(txt []byte, kind string, id uint8, t *Token) bool {
 	return true
 }
 
+// Reports whether kind is keyword.
+func Is_keyword(kind string) bool {
+	for _, pair := range _KEYWORDS {
+		if pair.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *_Lex) lex_kws(txt []byte, tok *Token) bool {
 	for _, pair := range _KEYWORDS {
 		if l.is_kw(txt, pair.kind, pair.id, tok) {
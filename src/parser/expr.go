@@ -978,6 +978,7 @@ func (ep *_ExprBuilder) build_from_tokens(tokens []lex.Token) *ast.Expr {
 	}
 	return &ast.Expr{
 		Token: tokens[0],
+		End:   tokens[len(tokens)-1],
 		Kind:  ep.build_kind(tokens),
 	}
 }
@@ -18,8 +18,10 @@ func compiler_err(token lex.Token, key string, args ...any) build.Log {
 		Type:   build.ERR,
 		Row:    token.Row,
 		Column: token.Column,
+		Length: len(token.Kind),
 		Path:   token.File.Path(),
 		Text:   build.Errorf(key, args...),
+		Code:   build.Code(key),
 	}
 }
 
@@ -98,8 +100,9 @@ func (p *_Parser) push_directive(c *ast.Comment) {
 	}
 
 	// Don't append if already added this directive.
+	// Allow directives may be repeated to suppress different checks.
 	for _, pd := range p.directives {
-		if d.Tag == pd.Tag {
+		if d.Tag == pd.Tag && d.Tag != build.DIRECTIVE_ALLOW {
 			return
 		}
 	}
@@ -166,6 +169,14 @@ func (p *_Parser) build_type_alias_decl(tokens []lex.Token) *ast.TypeAliasDecl {
 		p.push_err(tokens[i-1], "invalid_syntax")
 		return tad
 	}
+	generics_tokens := lex.Range(&i, lex.KND_LBRACKET, lex.KND_RBRACKET, tokens)
+	if generics_tokens != nil {
+		tad.Generics = p.build_generics(generics_tokens)
+	}
+	if i >= len(tokens) {
+		p.push_err(tokens[i-1], "invalid_syntax")
+		return tad
+	}
 	token = tokens[i]
 	if token.Id != lex.ID_COLON {
 		p.push_err(tokens[i-1], "invalid_syntax")
@@ -272,6 +283,8 @@ func (p *_Parser) build_var(tokens []lex.Token) *ast.VarDecl {
 	i := 0
 	v := &ast.VarDecl{
 		Token: tokens[i],
+		Begin: tokens[i],
+		End:   tokens[len(tokens)-1],
 	}
 	p.build_var_begin(v, &i, tokens)
 	if i >= len(tokens) {
@@ -655,6 +668,7 @@ func (p *_Parser) build_fn(tokens []lex.Token, method bool, anon bool, prototype
 	if block_tokens != nil {
 		f.Scope = p.build_scope(block_tokens)
 		f.Scope.Unsafety = f.Unsafety
+		f.Scope.End = tokens[i-1]
 		if i < len(tokens) {
 			p.push_err(tokens[i], "invalid_syntax")
 		}
@@ -689,12 +703,17 @@ func (p *_Parser) get_use_decl_selectors(tokens []lex.Token) []lex.Token {
 	return selectors
 }
 
+// Reports whether token is string literal.
+func is_str_lit(token lex.Token) bool {
+	return token.Id == lex.ID_LIT && (token.Kind[0] == '`' || token.Kind[0] == '"')
+}
+
 func (p *_Parser) build_cpp_use_decl(decl *ast.UseDecl, tokens []lex.Token) {
-	if len(tokens) > 2 {
-		p.push_err(tokens[2], "invalid_syntax")
+	if len(tokens) > 3 {
+		p.push_err(tokens[3], "invalid_syntax")
 	}
 	token := tokens[1]
-	if token.Id != lex.ID_LIT || (token.Kind[0] != '`' && token.Kind[0] != '"') {
+	if !is_str_lit(token) {
 		p.push_err(token, "invalid_expr")
 		return
 	}
@@ -703,6 +722,16 @@ func (p *_Parser) build_cpp_use_decl(decl *ast.UseDecl, tokens []lex.Token) {
 	if !build.Is_std_header_path(decl.Link_path) {
 		decl.Link_path = filepath.Join(token.File.Dir(), decl.Link_path)
 	}
+
+	// Compiler flags of source file.
+	if len(tokens) > 2 {
+		token = tokens[2]
+		if !is_str_lit(token) {
+			p.push_err(token, "invalid_expr")
+			return
+		}
+		decl.Cpp_flags = strings.TrimSpace(token.Kind[1 : len(token.Kind)-1])
+	}
 }
 
 func (p *_Parser) build_std_use_decl(decl *ast.UseDecl, tokens []lex.Token) {
@@ -1228,16 +1257,18 @@ func (p *_Parser) parse_impl_trait(ipl *ast.Impl, tokens []lex.Token) {
 		token := tokens[0]
 		switch token.Id {
 		case lex.ID_COMMENT:
-			// Ignore.
+			p.push_method_directive(token)
 			continue
 
 		case lex.ID_FN, lex.ID_UNSAFE:
 			f := p.get_method(tokens)
 			if f != nil {
 				f.Public = true
+				f.Directives = p.directives
 				p.check_method_receiver(f)
 				ipl.Methods = append(ipl.Methods, f)
 			}
+			p.directives = nil
 
 		default:
 			p.push_err(token, "invalid_syntax")
@@ -1254,7 +1285,7 @@ func (p *_Parser) parse_impl_struct(ipl *ast.Impl, tokens []lex.Token) {
 		is_pub := false
 		switch token.Id {
 		case lex.ID_COMMENT:
-			// Ignore.
+			p.push_method_directive(token)
 			continue
 
 		case lex.ID_PUB:
@@ -1274,9 +1305,11 @@ func (p *_Parser) parse_impl_struct(ipl *ast.Impl, tokens []lex.Token) {
 			f := p.get_method(tokens)
 			if f != nil {
 				f.Public = is_pub
+				f.Directives = p.directives
 				p.check_method_receiver(f)
 				ipl.Methods = append(ipl.Methods, f)
 			}
+			p.directives = nil
 
 		default:
 			p.push_err(token, "invalid_syntax")
@@ -1285,12 +1318,28 @@ func (p *_Parser) parse_impl_struct(ipl *ast.Impl, tokens []lex.Token) {
 	}
 }
 
+// Pushes directive of method in implementation body.
+// Other comments are ignored.
+func (p *_Parser) push_method_directive(token lex.Token) {
+	c := build_comment(token)
+	if c.Is_directive() {
+		p.push_directive(c)
+	}
+}
+
 func (p *_Parser) parse_impl_body(ipl *ast.Impl, tokens []lex.Token) {
+	// Directives of implementation are not belongs to methods.
+	p.check_non_fn_directives(p.directives)
+	p.directives = nil
+
 	if ipl.Is_trait_impl() {
 		p.parse_impl_trait(ipl, tokens)
-		return
+	} else {
+		p.parse_impl_struct(ipl, tokens)
 	}
-	p.parse_impl_struct(ipl, tokens)
+
+	// Drop remaining directives which are not followed by method.
+	p.directives = nil
 }
 
 func (p *_Parser) build_impl(tokens []lex.Token) *ast.Impl {
@@ -1412,6 +1461,16 @@ func (p *_Parser) check_comment_group(node ast.Node) {
 	}
 }
 
+// Checks directives of declaration that is not function.
+// Function attribute directives are allowed for functions only.
+func (p *_Parser) check_non_fn_directives(directives []*ast.Directive) {
+	for _, d := range directives {
+		if build.Is_fn_attribute_directive(d.Tag) {
+			p.push_err(d.Token, "directive_for_non_fn", d.Tag)
+		}
+	}
+}
+
 func (p *_Parser) check_directive(node ast.Node) {
 	if p.directives == nil {
 		return
@@ -1422,6 +1481,7 @@ func (p *_Parser) check_directive(node ast.Node) {
 		// Ignore
 
 	default:
+		p.check_non_fn_directives(p.directives)
 		p.directives = nil
 	}
 }
@@ -1434,6 +1494,9 @@ func (p *_Parser) apply_meta(node ast.Node, is_pub bool) {
 			return
 		}
 		v.Public = is_pub
+		v.Directives = p.directives
+		p.directives = nil
+		p.check_non_fn_directives(v.Directives)
 		v.Doc_comments = p.comment_group
 		is_pub = false
 		p.comment_group = nil
@@ -1466,6 +1529,9 @@ func (p *_Parser) apply_meta(node ast.Node, is_pub bool) {
 		if ed == nil {
 			return
 		}
+		ed.Directives = p.directives
+		p.directives = nil
+		p.check_non_fn_directives(ed.Directives)
 		ed.Doc_comments = p.comment_group
 		p.comment_group = nil
 		ed.Public = is_pub
@@ -1478,6 +1544,7 @@ func (p *_Parser) apply_meta(node ast.Node, is_pub bool) {
 		}
 		sd.Directives = p.directives
 		p.directives = nil
+		p.check_non_fn_directives(sd.Directives)
 		sd.Doc_comments = p.comment_group
 		p.comment_group = nil
 		sd.Public = is_pub
@@ -1488,6 +1555,9 @@ func (p *_Parser) apply_meta(node ast.Node, is_pub bool) {
 		if td == nil {
 			return
 		}
+		td.Directives = p.directives
+		p.directives = nil
+		p.check_non_fn_directives(td.Directives)
 		td.Doc_comments = p.comment_group
 		p.comment_group = nil
 		td.Public = is_pub
@@ -6,6 +6,7 @@ package parser
 
 import (
 	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
 	"github.com/julelang/jule/lex"
 )
 
@@ -465,8 +466,17 @@ func (sp *_ScopeParser) build_if_else_chain(tokens []lex.Token) ast.NodeData {
 	return chain
 }
 
+// Returns allow directive if comment is allow directive.
+// Other directives are not applicable to scopes, so they are comments.
 func (sp *_ScopeParser) build_comment_st(token lex.Token) ast.NodeData {
-	return build_comment(token)
+	c := build_comment(token)
+	if c.Is_directive() {
+		d := sp.p.get_directive(c)
+		if d != nil && d.Tag == build.DIRECTIVE_ALLOW {
+			return d
+		}
+	}
+	return c
 }
 
 func (sp *_ScopeParser) build_call_st(tokens []lex.Token) ast.NodeData {
@@ -533,6 +543,7 @@ func (sp *_ScopeParser) build_case_exprs(tokens *[]lex.Token, type_match bool) [
 				if ok {
 					exprs = append(exprs, &ast.Expr{
 						Token: token,
+						End:   tokens[i-1],
 						Kind:  t,
 					})
 				}
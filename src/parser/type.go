@@ -173,6 +173,9 @@ func (tb *_TypeBuilder) build_ident() *ast.TypeDecl {
 }
 
 func (tb *_TypeBuilder) build_cpp_link() *ast.TypeDecl {
+	if *tb.i+1 < len(tb.tokens) && tb.tokens[*tb.i+1].Id == lex.ID_FN {
+		return tb.build_c_fnptr()
+	}
 	if *tb.i+1 >= len(tb.tokens) || tb.tokens[*tb.i+1].Id != lex.ID_DOT {
 		tb.push_err(tb.tokens[*tb.i], "invalid_syntax")
 		return nil
@@ -195,6 +198,17 @@ func (tb *_TypeBuilder) build_fn() *ast.TypeDecl {
 	}
 }
 
+// Builds C function pointer type in "cpp fn(...)" form.
+func (tb *_TypeBuilder) build_c_fnptr() *ast.TypeDecl {
+	*tb.i++ // Skip cpp keyword.
+	t := tb.build_fn()
+	if t == nil {
+		return nil
+	}
+	t.Kind.(*ast.FnDecl).C_fnptr = true
+	return t
+}
+
 func (tb *_TypeBuilder) build_ptr() *ast.TypeDecl {
 	token := tb.tokens[*tb.i]
 	if *tb.i+1 >= len(tb.tokens) {
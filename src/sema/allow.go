@@ -0,0 +1,135 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+)
+
+// Suppression of warning class by allow directive.
+type _Allow struct {
+	token lex.Token // Token of directive.
+	class string
+	used  bool
+}
+
+// Builds suppressions of allow directive.
+// Reports invalid checks of directive.
+func (s *_Sema) build_allows(d *ast.Directive) []*_Allow {
+	var allows []*_Allow
+	missing := true
+	for _, arg := range d.Args {
+		if arg == "" {
+			continue
+		}
+		missing = false
+
+		if !build.Is_warn_class(arg) {
+			s.push_err(d.Token, "allow_directive_invalid_check", arg)
+			continue
+		}
+		allow := &_Allow{
+			token: d.Token,
+			class: arg,
+		}
+		allows = append(allows, allow)
+		s.allow_list = append(s.allow_list, allow)
+	}
+
+	if missing {
+		s.push_err(d.Token, "allow_directive_missing_check")
+	}
+	return allows
+}
+
+// Returns suppressions of allow directives.
+// Suppressions are built once for each directive,
+// so instances of generic functions share suppressions.
+func (s *_Sema) get_allows(directives []*ast.Directive) []*_Allow {
+	var allows []*_Allow
+	for _, d := range directives {
+		if d.Tag != build.DIRECTIVE_ALLOW {
+			continue
+		}
+
+		da, ok := s.allow_map[d]
+		if !ok {
+			da = s.build_allows(d)
+			if s.allow_map == nil {
+				s.allow_map = map[*ast.Directive][]*_Allow{}
+			}
+			s.allow_map[d] = da
+		}
+		allows = append(allows, da...)
+	}
+	return allows
+}
+
+// Pushes suppressions of directives over current suppressions.
+// Returns previous suppressions to restore.
+func (s *_Sema) push_allows(directives []*ast.Directive) []*_Allow {
+	prev := s.allows
+	allows := s.get_allows(directives)
+	if len(allows) > 0 {
+		// Always allocate new slice, previous suppressions may be remembered.
+		s.allows = append(prev[:len(prev):len(prev)], allows...)
+	}
+	return prev
+}
+
+// Sets suppressions of declaration directives as current suppressions.
+// Declarations are not affected by suppressions of where they are checked from.
+// Returns previous suppressions to restore.
+func (s *_Sema) enter_allows(directives ...[]*ast.Directive) []*_Allow {
+	prev := s.allows
+	s.allows = nil
+	for _, d := range directives {
+		_ = s.push_allows(d)
+	}
+	return prev
+}
+
+// Reports whether warning class is suppressed by current suppressions.
+// All suppressions of class are marked as used.
+func (s *_Sema) is_allowed(class string) bool {
+	allowed := false
+	for _, allow := range s.allows {
+		if allow.class == class {
+			allow.used = true
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// Same as push_warn, but uses suppressions instead of current suppressions.
+// Used for checks which are reported after checking scope of definition.
+func (s *_Sema) push_warn_with(allows []*_Allow, class string, token lex.Token, key string, args ...any) bool {
+	prev := s.allows
+	s.allows = allows
+	ok := s.push_warn(class, token, key, args...)
+	s.allows = prev
+	return ok
+}
+
+// Reports suppressions that never suppressed a log.
+// Suppressions of not reported warning classes are skipped.
+// Must be called after package checked.
+// Files have uninstantiated generics are skipped.
+func (s *_Sema) check_unused_allows() {
+	for _, allow := range s.allow_list {
+		if allow.used || build.Warn_level(allow.class) == build.WARN_LEVEL_OFF {
+			continue
+		}
+
+		f := find_file(s.files, allow.token.File)
+		if f != nil && has_unchecked_generics(f) {
+			continue
+		}
+		s.push_warn(build.WARN_UNUSED_ALLOW, allow.token, "unused_allow", allow.class)
+	}
+}
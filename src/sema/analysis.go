@@ -7,6 +7,7 @@ package sema
 import (
 	"github.com/julelang/jule/ast"
 	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
 )
 
 // Builds symbol table of AST.
@@ -35,21 +36,30 @@ func analyze_package(files []*ast.Ast, importer Importer) (*Package, []build.Log
 		tables[i] = table
 	}
 
-	sema := _Sema{}
+	sema := _Sema{
+		warnings: new([]build.Log),
+		exports:  map[string]lex.Token{},
+	}
 	sema.check(tables)
+	if len(sema.errors) == 0 {
+		sema.check_unused_imports()
+		sema.check_unused_allows()
+	}
 	if len(sema.errors) > 0 {
-		return nil, sema.errors
+		return nil, append(*sema.warnings, sema.errors...)
 	}
 
 	pkg := &Package{
 		Files: sema.files,
 	}
 
-	return pkg, nil
+	return pkg, *sema.warnings
 }
 
 // Builds symbol table of package's ASTs.
 // Returns nil if files is nil.
+// Returns nil package with warnings and errors if analysis fails.
+// Returns package with warnings if analysis succeeds.
 // Returns nil if pwd is empty.
 // Returns nil if pstd is empty.
 // Accepts current working directory is pwd.
@@ -77,6 +87,8 @@ func Analyze_package(files []*ast.Ast, importer Importer) (*Package, []build.Log
 
 // Builds symbol table of AST.
 // Returns nil if f is nil.
+// Returns nil table with warnings and errors if analysis fails.
+// Returns table with warnings if analysis succeeds.
 // Returns nil if pwd is empty.
 // Returns nil if pstd is empty.
 // Accepts current working directory is pwd.
@@ -96,13 +108,13 @@ func Analyze_package(files []*ast.Ast, importer Importer) (*Package, []build.Log
 //     semantic analyzer used nil importer.
 func Analyze_file(f *ast.Ast, importer Importer) (*SymbolTable, []build.Log) {
 	files := []*ast.Ast{f}
-	pkg, errors := Analyze_package(files, importer)
-	if len(errors) > 0 {
-		return nil, errors
+	pkg, logs := Analyze_package(files, importer)
+	if pkg == nil {
+		return nil, logs
 	}
 
 	// Select first table, because package has only one file.
 	// We give just one file.
 	table := pkg.Files[0]
-	return table, nil
+	return table, logs
 }
@@ -93,6 +93,24 @@ func init() {
 	}
 }
 
+// Identifiers of built-in defines.
+var builtin_idents = [...]string{
+	"out",
+	"outln",
+	"new",
+	"real",
+	"drop",
+	"panic",
+	"make",
+	"append",
+	"copy",
+	"recover",
+	"clone",
+	"byte",
+	"rune",
+	"Error",
+}
+
 func find_builtin_fn(ident string) *FnIns {
 	switch ident {
 	case "out":
@@ -4,7 +4,10 @@
 
 package sema
 
-import "github.com/julelang/jule/lex"
+import (
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/lex"
+)
 
 // Enum item.
 type EnumItem struct {
@@ -18,12 +21,13 @@ func (ei *EnumItem) Auto_expr() bool { return ei.Value == nil }
 
 // Enum.
 type Enum struct {
-	Token  lex.Token
-	Public bool
-	Ident  string
-	Kind   *TypeSymbol
-	Items  []*EnumItem
-	Doc    string
+	Token      lex.Token
+	Public     bool
+	Ident      string
+	Kind       *TypeSymbol
+	Items      []*EnumItem
+	Directives []*ast.Directive
+	Doc        string
 }
 
 // Implement: Kind
@@ -386,6 +386,7 @@ func find_builtins_sema(ident string, s *_Sema) any {
 		if imp.Import_all || imp.exist_ident(ident) {
 			def := find_builtins_import(ident, imp)
 			if def != nil {
+				imp.use(ident)
 				return def
 			}
 		}
@@ -524,10 +525,12 @@ func (e *_Eval) check_illegal_cycles(v *Var, decl_token lex.Token) (ok bool) {
 	const PADDING = 4
 
 	message := ""
+	var cycle []*Var // Variables of cycle in order of message.
 
 	push := func(v1 *Var, v2 *Var) {
 		refers_to := build.Errorf("refers_to", v1.Ident, v2.Ident)
 		message = strings.Repeat(" ", PADDING) + refers_to + "\n" + message
+		cycle = append([]*Var{v1}, cycle...)
 	}
 
 	// Check cross illegal cycle.
@@ -554,6 +557,11 @@ func (e *_Eval) check_illegal_cycles(v *Var, decl_token lex.Token) (ok bool) {
 		push(e.owner, v)
 		err_msg = err_msg + message
 		e.push_err(decl_token, "illegal_cross_cycle", err_msg)
+		// Owner is prepended last, but its line is the last one of message.
+		cycle = append(cycle[1:], cycle[0])
+		for _, v := range cycle {
+			e.s.push_err_note(v.Token, "declared_here", v.Ident)
+		}
 		return false
 	}
 
@@ -577,7 +585,7 @@ func (e *_Eval) eval_var(v *Var, error_token lex.Token) *Data {
 	if !v.Cpp_linked && (v.Value == nil || v.Value.Data == nil) {
 		if v.Constant {
 			// Eval constant dependent variable.
-			e.s.check_type_var(v, e.s)
+			e.s.check_type_global_var(v)
 			if v.Value.Data == nil {
 				// Skip error.
 				return nil
@@ -649,15 +657,19 @@ func (e *_Eval) eval_type_alias(ta *TypeAlias, error_token lex.Token) *Data {
 func (e *_Eval) eval_def(def any, ident lex.Token) *Data {
 	switch def.(type) {
 	case *Var:
+		e.s.check_deprecated(def.(*Var).Directives, ident.Kind, def.(*Var).Token, ident)
 		return e.eval_var(def.(*Var), ident)
 
 	case *Enum:
+		e.s.check_deprecated(def.(*Enum).Directives, ident.Kind, def.(*Enum).Token, ident)
 		return e.eval_enum(def.(*Enum), ident)
 
 	case *Struct:
+		e.s.check_deprecated(def.(*Struct).Directives, ident.Kind, def.(*Struct).Token, ident)
 		return e.eval_struct(def.(*Struct).instance(), ident)
 
 	case *Fn:
+		e.s.check_deprecated(def.(*Fn).Directives, ident.Kind, def.(*Fn).Token, ident)
 		return e.eval_fn(def.(*Fn), ident)
 
 	case *FnIns:
@@ -667,13 +679,20 @@ func (e *_Eval) eval_def(def any, ident lex.Token) *Data {
 		return e.eval_type_alias(def.(*TypeAlias), ident)
 
 	default:
-		e.push_err(ident, "ident_not_exist", ident.Kind)
+		const CPP_LINKED = false
+		idents := get_lookup_idents(e.lookup, CPP_LINKED)
+		e.s.push_suggested_err(ident, ident.Kind, idents, "ident_not_exist", ident.Kind)
 		return nil
 	}
 }
 
 func (e *_Eval) eval_ident(ident *ast.IdentExpr) *Data {
 	def := e.get_def(ident.Ident, ident.Cpp_linked)
+	if def == nil && ident.Cpp_linked {
+		idents := get_lookup_idents(e.lookup, ident.Cpp_linked)
+		e.s.push_suggested_err(ident.Token, ident.Ident, idents, "ident_not_exist", ident.Token.Kind)
+		return nil
+	}
 	return e.eval_def(def, ident.Token)
 }
 
@@ -797,10 +816,25 @@ func (e *_Eval) eval_unary_star(d *Data, op lex.Token) *Data {
 	return d
 }
 
-func (e *_Eval) eval_unary_amper(d *Data) *Data {
+// Reports whether model is integer field of structure with C layout.
+// These fields are stored as fixed-width C integers, not as Jule integers.
+func is_repr_c_int_field(m ExprModel) bool {
+	ssi, ok := m.(*StructSubIdentExprModel)
+	if !ok || ssi.Field == nil || !ssi.Field.Decl.Owner.Is_repr_c() {
+		return false
+	}
+	prim := ssi.Field.Kind.Prim()
+	return prim != nil && !is_platform_int(prim.kind) && types.Is_int(prim.kind)
+}
+
+func (e *_Eval) eval_unary_amper(d *Data, op lex.Token) *Data {
 	switch d.Model.(type) {
 	case *StructLitExprModel:
 		lit := d.Model.(*StructLitExprModel)
+		if lit.Strct.Decl.Is_repr_c() {
+			// Heap allocation requires self reference field.
+			e.push_err(op, "repr_c_heap_alloc", lit.Strct.Decl.Ident)
+		}
 		d.Kind = &TypeKind{
 			kind: &Ref{
 				Elem: &TypeKind{kind: lit.Strct},
@@ -822,6 +856,10 @@ func (e *_Eval) eval_unary_amper(d *Data) *Data {
 			}
 
 		case can_get_ptr(d):
+			if is_repr_c_int_field(d.Model) {
+				f := d.Model.(*StructSubIdentExprModel).Field
+				e.push_err(op, "repr_c_field_ptr", f.Decl.Ident)
+			}
 			d.Kind = &TypeKind{
 				kind: &Ptr{Elem: d.Kind.clone()},
 			}
@@ -871,7 +909,7 @@ func (e *_Eval) eval_unary(u *ast.UnaryExpr) *Data {
 		d = e.eval_unary_star(d, u.Op)
 
 	case lex.KND_AMPER:
-		d = e.eval_unary_amper(d)
+		d = e.eval_unary_amper(d, u.Op)
 
 	default:
 		d = nil
@@ -1548,9 +1586,10 @@ func (e *_Eval) eval_ns_selection(s *ast.NsSelectionExpr) *Data {
 	})
 
 	if imp == nil || !imp.is_lookupable(lex.KND_SELF) {
-		e.push_err(s.Ident, "namespace_not_exist", path)
+		e.s.push_suggested_err(s.Ident, path, e.s.get_namespaces(), "namespace_not_exist", path)
 		return nil
 	}
+	imp.use(lex.KND_SELF)
 
 	lookup := e.lookup
 	e.lookup = imp
@@ -1778,12 +1817,22 @@ func (e *_Eval) call_fn(fc *ast.FnCallExpr, d *Data) *Data {
 
 	if len(f.Generics) > 0 && is_unique_ins {
 		// Check generic function instance instantly.
+		n := len(e.s.errors)
 		e.s.check_fn_ins(f)
+		attach_instantiation_note(e.s.errors[n:], f, fc.Token)
 	}
 
 	return d
 }
 
+// Attaches note of instantiation to errors of generic function instance.
+func attach_instantiation_note(errors []build.Log, f *FnIns, token lex.Token) {
+	ident := f.get_ins_ident()
+	for i := range errors {
+		attach_note(&errors[i], token, "instantiated_here", ident)
+	}
+}
+
 func (e *_Eval) eval_fn_call(fc *ast.FnCallExpr) *Data {
 	d := e.eval_expr_kind(fc.Expr.Kind)
 	if d == nil {
@@ -1812,7 +1861,7 @@ func (e *_Eval) eval_enum_static(enm *Enum, ident lex.Token) *Data {
 
 	item := enm.Find_item(ident.Kind)
 	if item == nil {
-		e.push_err(ident, "obj_have_not_ident", ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_enum_idents(enm), "enum_have_not_field", ident.Kind)
 	} else {
 		d.Constant = new(constant.Const)
 		*d.Constant = *item.Value.Data.Constant
@@ -1825,7 +1874,7 @@ func (e *_Eval) eval_enum_static(enm *Enum, ident lex.Token) *Data {
 func (e *_Eval) eval_trait_sub_ident(d *Data, trt *Trait, ident lex.Token) *Data {
 	f := trt.Find_method(ident.Kind)
 	if f == nil {
-		e.push_err(ident, "obj_have_not_ident", ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_trait_idents(trt), "obj_have_not_ident", ident.Kind)
 		return nil
 	}
 
@@ -1887,9 +1936,11 @@ func (e *_Eval) eval_struct_sub_ident(d *Data, s *StructIns, si *ast.SubIdentExp
 
 	m := s.Find_method(si.Ident.Kind)
 	if m == nil {
-		e.push_err(si.Ident, "obj_have_not_ident", si.Ident.Kind)
+		idents := e.s.get_struct_idents(s)
+		e.s.push_suggested_err(si.Ident, si.Ident.Kind, idents, "obj_have_not_ident", si.Ident.Kind)
 		return nil
 	}
+	e.s.check_deprecated(m.Directives, s.Decl.Ident+"."+m.Ident, m.Token, si.Ident)
 
 	if m.Params[0].Is_ref() && !ref {
 		e.push_err(si.Ident, "ref_method_used_with_not_ref_instance")
@@ -2307,7 +2358,7 @@ func (e *_Eval) eval_int_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2325,7 +2376,7 @@ func (e *_Eval) eval_uint_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2353,7 +2404,7 @@ func (e *_Eval) eval_i8_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2381,7 +2432,7 @@ func (e *_Eval) eval_i16_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2409,7 +2460,7 @@ func (e *_Eval) eval_i32_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2437,7 +2488,7 @@ func (e *_Eval) eval_i64_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2456,7 +2507,7 @@ func (e *_Eval) eval_u8_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2475,7 +2526,7 @@ func (e *_Eval) eval_u16_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2494,7 +2545,7 @@ func (e *_Eval) eval_u32_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2513,7 +2564,7 @@ func (e *_Eval) eval_u64_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2541,7 +2592,7 @@ func (e *_Eval) eval_f32_type_tatic(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2569,7 +2620,7 @@ func (e *_Eval) eval_f64_type_static(ident lex.Token) *Data {
 		}
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2613,7 +2664,7 @@ func (e *_Eval) eval_prim_static(kind string, ident lex.Token) *Data {
 		return e.eval_f64_type_static(ident)
 
 	default:
-		e.push_err(ident, "type_have_not_ident", kind, ident.Kind)
+		e.s.push_suggested_err(ident, ident.Kind, get_prim_static_idents(kind), "type_have_not_ident", kind, ident.Kind)
 		return nil
 	}
 }
@@ -2824,7 +2875,11 @@ func (e *_Eval) eval_binop(op *ast.BinopExpr) *Data {
 	bs := _BinopSolver{
 		e: e,
 	}
-	return bs.solve(op)
+	d := bs.solve(op)
+	if d != nil {
+		e.s.check_self_compare(op, bs.l.Kind)
+	}
+	return d
 }
 
 func (e *_Eval) eval_expr_kind(kind ast.ExprData) *Data {
@@ -3708,6 +3763,31 @@ func (bs *_BinopSolver) solve_explicit(l *Data, r *Data) *Data {
 	return d
 }
 
+// Labels operands with their types for errors of operator.
+func (bs *_BinopSolver) label_operands(errors []build.Log, op *ast.BinopExpr, l *Data, r *Data) {
+	var spans []build.Span
+	push := func(e ast.ExprData, d *Data) {
+		token := get_expr_token(e)
+		if token.File != nil && token.File.Path() == bs.op.File.Path() {
+			spans = append(spans, build.Span{
+				Row:    token.Row,
+				Column: token.Column,
+				Length: len(token.Kind),
+				Label:  d.Kind.To_str(),
+			})
+		}
+	}
+	push(op.Left, l)
+	push(op.Right, r)
+
+	for i := range errors {
+		log := &errors[i]
+		if log.Row == bs.op.Row && log.Column == bs.op.Column && log.Spans == nil {
+			log.Spans = spans
+		}
+	}
+}
+
 func (bs *_BinopSolver) solve(op *ast.BinopExpr) *Data {
 	l := bs.e.eval_expr_kind(op.Left)
 	if l == nil || l.Kind == nil {
@@ -3721,7 +3801,9 @@ func (bs *_BinopSolver) solve(op *ast.BinopExpr) *Data {
 
 	bs.op = op.Op
 
+	n := len(bs.e.s.errors)
 	d := bs.solve_explicit(l, r)
+	bs.label_operands(bs.e.s.errors[n:], op, l, r)
 
 	// Save rune type.
 	if d != nil && l.Is_rune && r.Is_rune {
@@ -3730,3 +3812,62 @@ func (bs *_BinopSolver) solve(op *ast.BinopExpr) *Data {
 
 	return d
 }
+
+// Returns first token of expression.
+// Returns zero token if expression has not any token.
+func get_expr_token(e ast.ExprData) lex.Token {
+	switch e.(type) {
+	case *ast.Expr:
+		return e.(*ast.Expr).Token
+
+	case *ast.LitExpr:
+		return e.(*ast.LitExpr).Token
+
+	case *ast.IdentExpr:
+		return e.(*ast.IdentExpr).Token
+
+	case *ast.UnaryExpr:
+		return e.(*ast.UnaryExpr).Op
+
+	case *ast.UnsafeExpr:
+		return e.(*ast.UnsafeExpr).Token
+
+	case *ast.NsSelectionExpr:
+		ns := e.(*ast.NsSelectionExpr)
+		if len(ns.Ns) > 0 {
+			return ns.Ns[0]
+		}
+		return ns.Ident
+
+	case *ast.SubIdentExpr:
+		si := e.(*ast.SubIdentExpr)
+		if si.Expr != nil {
+			token := get_expr_token(si.Expr)
+			if token.File != nil {
+				return token
+			}
+		}
+		return si.Ident
+
+	case *ast.BinopExpr:
+		return get_expr_token(e.(*ast.BinopExpr).Left)
+
+	case *ast.FnCallExpr:
+		return get_expr_token(e.(*ast.FnCallExpr).Expr)
+
+	case *ast.IndexingExpr:
+		return get_expr_token(e.(*ast.IndexingExpr).Expr)
+
+	case *ast.SlicingExpr:
+		return get_expr_token(e.(*ast.SlicingExpr).Expr)
+
+	case *ast.SliceExpr:
+		return e.(*ast.SliceExpr).Token
+
+	case *ast.BraceLit:
+		return e.(*ast.BraceLit).Token
+
+	default:
+		return lex.Token{}
+	}
+}
@@ -218,3 +218,10 @@ type RuneExprModel struct {
 type ExplicitDerefExprModel struct {
 	Expr ExprModel
 }
+
+// Expression model for global function assigned to C function pointer.
+// Arguments and result are casted between C and Jule types.
+type CFnptrExprModel struct {
+	Kind *FnIns // Type of C function pointer.
+	Func *FnIns
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"os"
+	"strings"
+
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/types"
+)
+
+// Column width of tab characters, must be same with lexer.
+const _TAB_WIDTH = 4
+
+// Returns edit which inserts text before token.
+func insert_edit(token lex.Token, text string) build.Edit {
+	return build.Edit{
+		Row:        token.Row,
+		Column:     token.Column,
+		End_row:    token.Row,
+		End_column: token.Column,
+		Text:       text,
+	}
+}
+
+// Returns row and column of end of token.
+// Tokens such as raw strings may have multiple lines.
+func token_end(token lex.Token) (int, int) {
+	n := strings.Count(token.Kind, "\n")
+	if n == 0 {
+		return token.Row, token.Column + len(token.Kind)
+	}
+	last := token.Kind[strings.LastIndexByte(token.Kind, '\n')+1:]
+	return token.Row + n, len(last) + 1
+}
+
+// Returns edit which inserts text after token.
+func append_edit(token lex.Token, text string) build.Edit {
+	row, column := token_end(token)
+	return build.Edit{
+		Row:        row,
+		Column:     column,
+		End_row:    row,
+		End_column: column,
+		Text:       text,
+	}
+}
+
+// Returns edit which removes tokens from begin to end, end is inclusive.
+func remove_edit(begin lex.Token, end lex.Token) build.Edit {
+	row, column := token_end(end)
+	return build.Edit{
+		Row:        begin.Row,
+		Column:     begin.Column,
+		End_row:    row,
+		End_column: column,
+	}
+}
+
+// Returns indentation of line which starts with token.
+// Indentation of tab columns are tabs as Jule code style.
+func get_indent(token lex.Token) string {
+	n := token.Column - 1
+	if n%_TAB_WIDTH == 0 {
+		return strings.Repeat("\t", n/_TAB_WIDTH)
+	}
+	return strings.Repeat(" ", n)
+}
+
+// Returns indentation of line of token in source file.
+// Returns indentation by column of token if source is not readable.
+func get_line_indent(token lex.Token) string {
+	bytes, err := os.ReadFile(token.File.Path())
+	if err == nil {
+		lines := strings.Split(string(bytes), "\n")
+		if token.Row <= len(lines) {
+			line := lines[token.Row-1]
+			return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+	}
+	return get_indent(token)
+}
+
+// Attaches fix to last pushed error.
+func (s *_Sema) push_err_fix(edits ...build.Edit) {
+	if len(edits) > 0 && len(s.errors) > 0 {
+		s.errors[len(s.errors)-1].Edits = edits
+	}
+}
+
+// Attaches fix to last pushed log of warning class.
+func (s *_Sema) push_warn_fix(class string, edits ...build.Edit) {
+	logs := s.warnings
+	if build.Warn_level(class) != build.WARN_LEVEL_WARN {
+		logs = &s.errors
+	}
+
+	if len(edits) > 0 && len(*logs) > 0 {
+		(*logs)[len(*logs)-1].Edits = edits
+	}
+}
+
+// Reports whether expression is side-effect free.
+// Function calls and other expressions are not supported.
+func is_pure_expr(e ast.ExprData) bool {
+	_, ok := get_expr_path(e)
+	if ok {
+		return true
+	}
+
+	switch e.(type) {
+	case *ast.Expr:
+		return is_pure_expr(e.(*ast.Expr).Kind)
+
+	case *ast.UnaryExpr:
+		return is_pure_expr(e.(*ast.UnaryExpr).Expr)
+
+	case *ast.BinopExpr:
+		binop := e.(*ast.BinopExpr)
+		return is_pure_expr(binop.Left) && is_pure_expr(binop.Right)
+
+	default:
+		return false
+	}
+}
+
+// Returns fix which removes declaration of unused variable.
+// Returns nil if variable is not declared by let statement
+// or initializer expression may have side-effects.
+func remove_var_fix(v *Var) []build.Edit {
+	if v.Begin.File == nil || v.Begin.Id != lex.ID_LET {
+		return nil
+	}
+	if v.Value != nil && !is_pure_expr(v.Value.Expr) {
+		return nil
+	}
+	return []build.Edit{remove_edit(v.Begin, v.End)}
+}
+
+// Returns fix which makes variable of data mutable.
+// Returns nil if data is not variable declared by let statement
+// in the same file with error.
+func add_mut_fix(d *Data, error_token lex.Token) []build.Edit {
+	v, ok := d.Model.(*Var)
+	if !ok || v.Begin.Id != lex.ID_LET || v.Token.File != error_token.File {
+		return nil
+	}
+	return []build.Edit{insert_edit(v.Token, lex.KND_MUT+" ")}
+}
+
+// Returns zero value expression of type.
+// Reports whether type has simple zero value expression.
+func get_zero_expr(t *TypeKind) (string, bool) {
+	switch {
+	case t.Ptr() != nil:
+		return lex.KND_NIL, true
+
+	case t.Prim() != nil:
+		prim := t.Prim()
+		switch {
+		case prim.Is_bool():
+			return lex.KND_FALSE, true
+
+		case prim.Is_str():
+			return `""`, true
+
+		case types.Is_num(prim.kind):
+			return "0", true
+		}
+	}
+	return "", false
+}
+
+// Returns fix which inserts return statement at end of function body.
+// Returns nil for generic functions and result types without zero value expression.
+func add_ret_fix(f *FnIns) []build.Edit {
+	end := f.Decl.Scope.End
+	if end.File == nil || end.Row <= f.Decl.Token.Row || len(f.Decl.Generics) > 0 ||
+		(f.Decl.Owner != nil && len(f.Decl.Owner.Generics) > 0) {
+		return nil
+	}
+
+	expr, ok := get_zero_expr(f.Result)
+	if !ok {
+		return nil
+	}
+
+	indent := get_line_indent(end)
+	return []build.Edit{insert_edit(end, get_body_indent_unit(f.Decl, indent)+lex.KND_RET+" "+expr+"\n"+indent)}
+}
+
+// Returns indentation unit of function body by first statement.
+// Indent is the indentation of closing brace of body.
+// Labels are skipped because they may be outdented.
+// Returns tab if unit cannot be derived.
+func get_body_indent_unit(f *Fn, indent string) string {
+	for _, node := range f.Scope.Stmts {
+		_, label := node.(*ast.LabelSt)
+		token := get_node_token(node)
+		if label || token.File == nil {
+			continue
+		}
+		if token.Row <= f.Token.Row {
+			break
+		}
+
+		body := get_line_indent(token)
+		if len(body) > len(indent) && strings.HasPrefix(body, indent) {
+			return body[len(indent):]
+		}
+		break
+	}
+	return "\t"
+}
+
+// Attaches cast fix to last pushed error if error is incompatible types
+// of numeric types at error token.
+func (s *_Sema) push_cast_fix(dest *TypeKind, d *Data, expr *ast.Expr, error_token lex.Token) {
+	if len(s.errors) == 0 || expr == nil || expr.End.File == nil {
+		return
+	}
+
+	l := &s.errors[len(s.errors)-1]
+	if l.Code != build.Code("incompatible_types") ||
+		l.Row != error_token.Row || l.Column != error_token.Column {
+		return
+	}
+
+	if dest.Prim() == nil || d.Kind.Prim() == nil ||
+		!types.Is_num(dest.Prim().kind) || !types.Is_num(d.Kind.Prim().kind) {
+		return
+	}
+
+	l.Edits = []build.Edit{
+		insert_edit(expr.Token, dest.To_str()+lex.KND_LPAREN),
+		append_edit(expr.End, lex.KND_RPARENT),
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/constant"
+	"github.com/julelang/jule/lex"
+)
+
+// Control flow checker of function scope.
+// Reports unreachable statements and match cases.
+type _FlowChecker struct {
+	s       *_Sema
+	tokens  map[any]lex.Token // Tokens of statements and cases.
+	allows  map[any][]*_Allow // Suppressions of scopes, statements and cases.
+	targets []string          // Labels targeted by goto statements.
+}
+
+// Reports whether statement is call of built-in panic function.
+func is_panic_call(st St) bool {
+	switch st.(type) {
+	case *Data:
+		_, ok := st.(*Data).Model.(*BuiltinPanicCallExprModel)
+		return ok
+
+	default:
+		return false
+	}
+}
+
+// Returns child scopes of statement.
+// Scopes of anonymous functions are not included.
+func get_child_scopes(st St) []*Scope {
+	var scopes []*Scope
+	switch st.(type) {
+	case *Scope:
+		scopes = append(scopes, st.(*Scope))
+
+	case *Recover:
+		scopes = append(scopes, st.(*Recover).Scope)
+
+	case *InfIter:
+		scopes = append(scopes, st.(*InfIter).Scope)
+
+	case *WhileIter:
+		scopes = append(scopes, st.(*WhileIter).Scope)
+
+	case *RangeIter:
+		scopes = append(scopes, st.(*RangeIter).Scope)
+
+	case *Conditional:
+		c := st.(*Conditional)
+		for _, elif := range c.Elifs {
+			if elif != nil {
+				scopes = append(scopes, elif.Scope)
+			}
+		}
+		if c.Default != nil {
+			scopes = append(scopes, c.Default.Scope)
+		}
+
+	case *Match:
+		m := st.(*Match)
+		for _, c := range m.Cases {
+			scopes = append(scopes, c.Scope)
+		}
+		if m.Default != nil {
+			scopes = append(scopes, m.Default.Scope)
+		}
+	}
+	return scopes
+}
+
+// Reports whether scope has break statement for iteration or match.
+// Target is the pointer of iteration or match.
+func has_break_to(s *Scope, target uintptr) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, st := range s.Stmts {
+		b, ok := st.(*BreakSt)
+		if ok {
+			if b != nil && (b.It == target || b.Mtch == target) {
+				return true
+			}
+			continue
+		}
+
+		for _, child := range get_child_scopes(st) {
+			if has_break_to(child, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Reports whether statement is label targeted by goto statement.
+func (fc *_FlowChecker) is_target(st St) bool {
+	label, ok := st.(*Label)
+	if !ok || label == nil {
+		return false
+	}
+
+	for _, target := range fc.targets {
+		if target == label.Ident {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether control never continues after scope.
+func (fc *_FlowChecker) is_terminating_scope(s *Scope) bool {
+	if s == nil {
+		return false
+	}
+
+	terminated := false
+	for _, st := range s.Stmts {
+		if fc.is_target(st) {
+			terminated = false
+		}
+		if !terminated {
+			terminated = fc.is_terminating(st)
+		}
+	}
+	return terminated
+}
+
+// Reports whether control never continues after match from case.
+func (fc *_FlowChecker) is_terminating_case(m *Match, c *Case) bool {
+	return fc.is_terminating_scope(c.Scope) && !has_break_to(c.Scope, _uintptr(m))
+}
+
+// Reports whether control never continues to next statement.
+func (fc *_FlowChecker) is_terminating(st St) bool {
+	switch st.(type) {
+	case *RetSt, *BreakSt, *ContSt, *GotoSt, *FallSt:
+		return true
+
+	case *Scope:
+		return fc.is_terminating_scope(st.(*Scope))
+
+	case *Recover:
+		return fc.is_terminating_scope(st.(*Recover).Scope)
+
+	case *InfIter:
+		it := st.(*InfIter)
+		return !has_break_to(it.Scope, _uintptr(it))
+
+	case *Conditional:
+		c := st.(*Conditional)
+		if c.Default == nil || !fc.is_terminating_scope(c.Default.Scope) {
+			return false
+		}
+
+		for _, elif := range c.Elifs {
+			if elif == nil || !fc.is_terminating_scope(elif.Scope) {
+				return false
+			}
+		}
+		return true
+
+	case *Match:
+		m := st.(*Match)
+		if m.Default == nil {
+			return false
+		}
+
+		for _, c := range m.Cases {
+			if !fc.is_terminating_case(m, c) {
+				return false
+			}
+		}
+		return fc.is_terminating_case(m, m.Default)
+
+	default:
+		return is_panic_call(st)
+	}
+}
+
+// Returns token of statement.
+// Returns token of first statement for anonymous scopes.
+func (fc *_FlowChecker) get_token(st St) lex.Token {
+	token := fc.tokens[st]
+	if token.File != nil {
+		return token
+	}
+
+	s, ok := st.(*Scope)
+	if ok {
+		for _, child := range s.Stmts {
+			token = fc.get_token(child)
+			if token.File != nil {
+				return token
+			}
+		}
+	}
+	return token
+}
+
+// Same as push_warn, but uses suppressions of statement or case if remembered.
+func (fc *_FlowChecker) push_warn(node any, class string, token lex.Token, key string) {
+	allows, ok := fc.allows[node]
+	if !ok {
+		fc.s.push_warn(class, token, key)
+		return
+	}
+	fc.s.push_warn_with(allows, class, token, key)
+}
+
+func (fc *_FlowChecker) push_unreachable(st St) {
+	token := fc.get_token(st)
+	if token.File != nil {
+		fc.push_warn(st, build.WARN_UNREACHABLE, token, "unreachable_code")
+	}
+}
+
+func (fc *_FlowChecker) push_unreachable_case(c *Case) {
+	token := fc.tokens[c]
+	if token.File != nil {
+		fc.push_warn(c, build.WARN_UNREACHABLE, token, "unreachable_case")
+	}
+}
+
+// Reports whether case never matches.
+// Consts are the constant expressions of previous cases.
+func is_unreachable_case(m *Match, c *Case, consts []*constant.Const) bool {
+	if len(c.Exprs) == 0 {
+		return false
+	}
+
+	expr, _ := m.Expr.(*constant.Const)
+	for _, e := range c.Exprs {
+		cnst, ok := e.(*constant.Const)
+		if !ok {
+			return false
+		}
+
+		if expr != nil && !expr.Eqs(*cnst) {
+			continue
+		}
+
+		duplicated := false
+		for _, prev := range consts {
+			if prev.Eqs(*cnst) {
+				duplicated = true
+				break
+			}
+		}
+		if !duplicated {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether case always matches.
+func is_always_case(m *Match, c *Case) bool {
+	expr, ok := m.Expr.(*constant.Const)
+	if !ok {
+		return false
+	}
+
+	for _, e := range c.Exprs {
+		cnst, ok := e.(*constant.Const)
+		if ok && expr.Eqs(*cnst) {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks cases of match that never matches.
+func (fc *_FlowChecker) check_match_cases(m *Match) {
+	if m.Type_match {
+		return
+	}
+
+	var consts []*constant.Const
+	for i, c := range m.Cases {
+		if is_unreachable_case(m, c, consts) {
+			fc.push_unreachable_case(c)
+		}
+
+		if is_always_case(m, c) {
+			for _, next := range m.Cases[i+1:] {
+				fc.push_unreachable_case(next)
+			}
+			if m.Default != nil {
+				fc.push_unreachable_case(m.Default)
+			}
+			return
+		}
+
+		for _, e := range c.Exprs {
+			cnst, ok := e.(*constant.Const)
+			if ok {
+				consts = append(consts, cnst)
+			}
+		}
+	}
+}
+
+// Checks unreachable statements of scope.
+// Reports only first statement of each unreachable statement sequence.
+func (fc *_FlowChecker) check_scope(s *Scope) {
+	if s == nil {
+		return
+	}
+
+	allows, ok := fc.allows[s]
+	if ok {
+		prev := fc.s.allows
+		fc.s.allows = allows
+		defer func() { fc.s.allows = prev }()
+	}
+
+	terminated := false
+	reported := false
+	for _, st := range s.Stmts {
+		if fc.is_target(st) {
+			terminated = false
+			reported = false
+		}
+
+		if terminated {
+			if !reported {
+				fc.push_unreachable(st)
+				reported = true
+			}
+			continue
+		}
+
+		m, ok := st.(*Match)
+		if ok {
+			fc.check_match_cases(m)
+		}
+
+		for _, child := range get_child_scopes(st) {
+			fc.check_scope(child)
+		}
+		terminated = fc.is_terminating(st)
+	}
+}
+
+// Checks control flow of function scope.
+// Must be called by root scope checker.
+func (sc *_ScopeChecker) check_flow(s *Scope) {
+	// Models may be incomplete if there is error.
+	if len(sc.s.errors) > 0 {
+		return
+	}
+
+	fc := _FlowChecker{
+		s:      sc.s,
+		tokens: sc.tokens,
+		allows: sc.allows,
+	}
+	for _, gt := range *sc.gotos {
+		fc.targets = append(fc.targets, gt.gt.Label.Kind)
+	}
+	fc.check_scope(s)
+}
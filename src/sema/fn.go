@@ -49,6 +49,7 @@ type Fn struct {
 	Unsafety   bool
 	Public     bool
 	Cpp_linked bool
+	C_fnptr    bool // C function pointer type.
 	Ident      string
 	Directives []*ast.Directive
 	Doc        string
@@ -78,6 +79,23 @@ func (f *Fn) Is_anon() bool { return lex.Is_anon_ident(f.Ident) }
 // Reports whether function has return variable(s).
 func (f *Fn) Any_var() bool { return f.Result != nil && len(f.Result.Idents) > 0 }
 
+// Returns symbol identifier of exported function.
+// Returns empty string if function is not exported.
+// Symbol identifier is function identifier if not specified by directive.
+func (f *Fn) Export_ident() string {
+	for _, d := range f.Directives {
+		if d.Tag != build.DIRECTIVE_EXPORT {
+			continue
+		}
+
+		if len(d.Args) > 0 && d.Args[0] != "" {
+			return d.Args[0]
+		}
+		return f.Ident
+	}
+	return ""
+}
+
 // Reports whether any parameter uses generic types.
 func (f *Fn) Parameters_uses_generics() bool {
 	if len(f.Generics) == 0 {
@@ -226,8 +244,27 @@ func (f *FnIns) Is_anon() bool { return f.anon || f.Decl != nil && f.Decl.Is_ano
 
 // Implement: Kind
 // Returns Fn's type kind as string.
+// Returns identifier of instance with generic types such as "f[int,str]".
+func (f *FnIns) get_ins_ident() string {
+	ident := f.Decl.Ident
+	if len(f.Generics) > 0 {
+		ident += "["
+		for i, t := range f.Generics {
+			ident += t.To_str()
+			if i+1 < len(f.Generics) {
+				ident += ","
+			}
+		}
+		ident += "]"
+	}
+	return ident
+}
+
 func (f FnIns) To_str() string {
 	s := ""
+	if f.Decl.C_fnptr {
+		s += lex.KND_CPP + " "
+	}
 	if f.Decl.Unsafety {
 		s += "unsafe "
 	}
@@ -125,6 +125,9 @@ type ImportInfo struct {
 	// Is cpp use declaration.
 	Cpp_linked bool
 
+	// Compiler flags of cpp source use declaration.
+	Cpp_flags string
+
 	// Is standard library package.
 	Std bool
 
@@ -134,6 +137,12 @@ type ImportInfo struct {
 	// Identifiers of selected definition.
 	Selected []lex.Token
 
+	// Is package referenced by namespace or any definition.
+	Used bool
+
+	// Identifiers of referenced selections.
+	Used_selected []string
+
 	// Nil if package is cpp header.
 	Package *Package
 }
@@ -239,6 +248,25 @@ func (i *ImportInfo) is_lookupable(ident string) bool {
 	return true
 }
 
+// Marks package and selection of identifier as used.
+func (i *ImportInfo) use(ident string) {
+	i.Used = true
+	if i.Import_all || !i.exist_ident(ident) || i.is_used_selection(ident) {
+		return
+	}
+	i.Used_selected = append(i.Used_selected, ident)
+}
+
+// Reports whether selection of identifier is used.
+func (i *ImportInfo) is_used_selection(ident string) bool {
+	for _, used := range i.Used_selected {
+		if used == ident {
+			return true
+		}
+	}
+	return false
+}
+
 // Reports whether identifier is selected.
 func (i *ImportInfo) exist_ident(ident string) bool {
 	for _, sident := range i.Selected {
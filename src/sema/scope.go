@@ -4,6 +4,7 @@ import (
 	"unsafe"
 
 	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
 	"github.com/julelang/jule/constant"
 	"github.com/julelang/jule/lex"
 	"github.com/julelang/jule/types"
@@ -138,11 +139,12 @@ type Recover struct {
 }
 
 type _ScopeLabel struct {
-	token lex.Token
-	label *Label
-	pos   int
-	scope *_ScopeChecker
-	used  bool
+	token  lex.Token
+	label  *Label
+	pos    int
+	scope  *_ScopeChecker
+	used   bool
+	allows []*_Allow // Suppressions of label.
 }
 
 type _ScopeGoto struct {
@@ -162,8 +164,10 @@ type _ScopeChecker struct {
 	tree        *ast.ScopeTree
 	it          uintptr
 	cse         uintptr
-	labels      *[]*_ScopeLabel // All labels of all scopes.
-	gotos       *[]*_ScopeGoto  // All gotos of all scopes.
+	labels      *[]*_ScopeLabel   // All labels of all scopes.
+	gotos       *[]*_ScopeGoto    // All gotos of all scopes.
+	tokens      map[any]lex.Token // Tokens of statements and cases of all scopes.
+	allows      map[any][]*_Allow // Suppressions of scopes, statements, cases, variables and type aliases.
 	i           int
 }
 
@@ -350,18 +354,19 @@ func (sc *_ScopeChecker) find_label_all(ident string) *_ScopeLabel {
 // Reports this identifier duplicated in scope.
 // The "self" parameter represents address of exception identifier.
 // If founded identifier address equals to self, will be skipped.
-func (sc *_ScopeChecker) is_duplicated_ident(itself uintptr, ident string) bool {
+// Returns token of duplicated define as first result.
+func (sc *_ScopeChecker) find_duplicated_ident(itself uintptr, ident string) (lex.Token, bool) {
 	v := sc.Find_var(ident, false)
 	if v != nil && _uintptr(v) != itself && v.Scope == sc.tree {
-		return true
+		return v.Token, true
 	}
 
 	ta := sc.Find_type_alias(ident, false)
 	if ta != nil && _uintptr(ta) != itself && ta.Scope == sc.tree {
-		return true
+		return ta.Token, true
 	}
 
-	return false
+	return lex.Token{}, false
 }
 
 func (sc *_ScopeChecker) check_var_decl(decl *ast.VarDecl) {
@@ -372,9 +377,11 @@ func (sc *_ScopeChecker) check_var_decl(decl *ast.VarDecl) {
 		sc.scope.Stmts = append(sc.scope.Stmts, v)
 	}()
 
-	if sc.is_duplicated_ident(_uintptr(v), v.Ident) {
-		sc.s.push_err(v.Token, "duplicated_ident", v.Ident)
+	prev, ok := sc.find_duplicated_ident(_uintptr(v), v.Ident)
+	if ok {
+		sc.s.push_duplicated_ident(v.Token, v.Ident, prev)
 	}
+	sc.check_shadowing(v)
 
 	sc.s.check_var_decl(v, sc)
 	if !v.Is_auto_typed() && (v.Kind == nil || v.Kind.Kind == nil) {
@@ -386,8 +393,9 @@ func (sc *_ScopeChecker) check_var_decl(decl *ast.VarDecl) {
 
 func (sc *_ScopeChecker) check_type_alias_decl(decl *ast.TypeAliasDecl) {
 	ta := build_type_alias(decl)
-	if sc.is_duplicated_ident(_uintptr(ta), ta.Ident) {
-		sc.s.push_err(ta.Token, "duplicated_ident", ta.Ident)
+	prev, ok := sc.find_duplicated_ident(_uintptr(ta), ta.Ident)
+	if ok {
+		sc.s.push_duplicated_ident(ta.Token, ta.Ident, prev)
 	}
 	sc.s.check_type_alias_decl(ta, sc)
 
@@ -418,6 +426,7 @@ func (sc *_ScopeChecker) check_child(tree *ast.ScopeTree) *Scope {
 }
 
 func (sc *_ScopeChecker) check_anon_scope(tree *ast.ScopeTree) {
+	sc.check_defer_in_loop(tree)
 	s := sc.check_child(tree)
 	sc.scope.Stmts = append(sc.scope.Stmts, s)
 }
@@ -454,6 +463,7 @@ func (sc *_ScopeChecker) check_expr(expr *ast.Expr) {
 		if ok {
 			return
 		}
+		sc.s.check_ignored_error(expr, d)
 	}
 
 	sc.scope.Stmts = append(sc.scope.Stmts, d)
@@ -477,6 +487,7 @@ func (sc *_ScopeChecker) check_if(i *ast.If) *If {
 		sc.s.push_err(i.Expr.Token, "if_require_bool_expr")
 		return nil
 	}
+	sc.s.check_const_cond(d, i.Expr.Token)
 
 	return &If{
 		Expr:  d.Model,
@@ -641,10 +652,12 @@ func (sc *_ScopeChecker) check_range_iter(it *ast.Iter) {
 	ssc := sc.new_child_checker()
 
 	if kind.Key_a != nil {
+		ssc.check_shadowing(kind.Key_a)
 		ssc.table.Vars = append(ssc.table.Vars, kind.Key_a)
 	}
 
 	if kind.Key_b != nil {
+		ssc.check_shadowing(kind.Key_b)
 		ssc.table.Vars = append(ssc.table.Vars, kind.Key_b)
 	}
 
@@ -790,10 +803,11 @@ func (sc *_ScopeChecker) check_label(l *ast.LabelSt) {
 
 	sc.scope.Stmts = append(sc.scope.Stmts, label)
 	*sc.labels = append(*sc.labels, &_ScopeLabel{
-		token: l.Token,
-		label: label,
-		pos:   len(sc.scope.Stmts) - 1,
-		scope: sc,
+		token:  l.Token,
+		label:  label,
+		pos:    len(sc.scope.Stmts) - 1,
+		scope:  sc,
+		allows: sc.s.allows,
 	})
 }
 
@@ -883,11 +897,16 @@ func (sc *_ScopeChecker) check_single_assign(a *ast.AssignSt) {
 		return
 	}
 
-	sc.scope.Stmts = append(sc.scope.Stmts, &Assign{
+	if a.Setter.Kind == lex.KND_EQ {
+		sc.s.check_self_assign(a.L[0].Expr, a.R)
+	}
+
+	assign := &Assign{
 		L:  l.Model,
 		R:  r.Model,
 		Op: a.Setter.Kind,
-	})
+	}
+	sc.scope.Stmts = append(sc.scope.Stmts, assign)
 
 	if a.Setter.Kind != lex.KND_EQ && !r.Is_const() {
 		a.Setter.Kind = a.Setter.Kind[:len(a.Setter.Kind)-1]
@@ -915,7 +934,16 @@ func (sc *_ScopeChecker) check_single_assign(a *ast.AssignSt) {
 		error_token: a.Setter,
 		deref:       true,
 	}
-	checker.check()
+	switch {
+	case checker.check():
+		if a.Setter.Kind == lex.KND_EQ {
+			// Checking may lower model, such as C function pointers.
+			assign.R = r.Model
+		}
+
+	case a.Setter.Kind == lex.KND_EQ:
+		sc.s.push_cast_fix(l.Kind, r, a.R, a.Setter)
+	}
 }
 
 func (sc *_ScopeChecker) check_multi_assign(a *ast.AssignSt) {
@@ -971,6 +999,7 @@ func (sc *_ScopeChecker) check_multi_assign(a *ast.AssignSt) {
 			}
 
 			sc.s.check_var(v)
+			sc.check_shadowing(v)
 
 			st.L = append(st.L, v)
 			sc.table.Vars = append(sc.table.Vars, v)
@@ -980,7 +1009,9 @@ func (sc *_ScopeChecker) check_multi_assign(a *ast.AssignSt) {
 		}
 
 		if lexpr.Mutable {
-			sc.s.push_err(lexpr.Token, "duplicated_ident", lexpr.Ident)
+			const ITSELF = 0
+			prev, _ := sc.table.find_duplicated_ident(ITSELF, lexpr.Ident, false)
+			sc.s.push_duplicated_ident(lexpr.Token, lexpr.Ident, prev)
 		}
 
 		l := sc.s.eval(lexpr.Expr, sc)
@@ -1032,6 +1063,8 @@ func (sc *_ScopeChecker) check_case_scope(c *Case, tree *ast.ScopeTree) *Scope {
 func (sc *_ScopeChecker) check_case(m *Match, i int, c *ast.Case, expr *Data) *Case {
 	_case := m.Cases[i]
 	_case.Exprs = make([]ExprModel, len(c.Exprs))
+	sc.tokens[_case] = c.Token
+	sc.allows[_case] = sc.s.allows
 
 	for i, e := range c.Exprs {
 		if m.Type_match {
@@ -1104,6 +1137,8 @@ func (sc *_ScopeChecker) check_default(m *Match, d *ast.Else) *Case {
 	def := &Case{
 		Owner: m,
 	}
+	sc.tokens[def] = d.Token
+	sc.allows[def] = sc.s.allows
 	def.Scope = sc.check_case_scope(def, d.Scope)
 	return def
 }
@@ -1316,6 +1351,10 @@ func (sc *_ScopeChecker) check_node(node ast.NodeData) {
 		// Ignore.
 		break
 
+	case *ast.Directive:
+		// Applied by check_tree.
+		break
+
 	case *ast.ScopeTree:
 		sc.check_anon_scope(node.(*ast.ScopeTree))
 
@@ -1363,11 +1402,105 @@ func (sc *_ScopeChecker) check_node(node ast.NodeData) {
 	}
 }
 
+// Returns token of statement node.
+// Returns empty token if node has not token.
+func get_node_token(node ast.NodeData) lex.Token {
+	switch node.(type) {
+	case *ast.VarDecl:
+		return node.(*ast.VarDecl).Token
+
+	case *ast.TypeAliasDecl:
+		return node.(*ast.TypeAliasDecl).Token
+
+	case *ast.Expr:
+		return node.(*ast.Expr).Token
+
+	case *ast.Conditional:
+		return node.(*ast.Conditional).Head.Token
+
+	case *ast.Iter:
+		return node.(*ast.Iter).Token
+
+	case *ast.ContSt:
+		return node.(*ast.ContSt).Token
+
+	case *ast.LabelSt:
+		return node.(*ast.LabelSt).Token
+
+	case *ast.GotoSt:
+		return node.(*ast.GotoSt).Token
+
+	case *ast.AssignSt:
+		l := node.(*ast.AssignSt).L[0]
+		if l.Expr != nil {
+			return l.Expr.Token
+		}
+		return l.Token
+
+	case *ast.MatchCase:
+		return node.(*ast.MatchCase).Token
+
+	case *ast.FallSt:
+		return node.(*ast.FallSt).Token
+
+	case *ast.BreakSt:
+		return node.(*ast.BreakSt).Token
+
+	case *ast.RetSt:
+		return node.(*ast.RetSt).Token
+
+	default:
+		return lex.Token{}
+	}
+}
+
+// Checks statements of scope tree.
+// Directives are applied to the following statement only.
 func (sc *_ScopeChecker) check_tree() {
+	var directives []*ast.Directive
 	sc.i = 0
 	for ; sc.i < len(sc.tree.Stmts); sc.i++ {
-		sc.check_node(sc.tree.Stmts[sc.i])
+		node := sc.tree.Stmts[sc.i]
+		switch node.(type) {
+		case *ast.Directive:
+			directives = append(directives, node.(*ast.Directive))
+			continue
+
+		case *ast.Comment:
+			continue
+		}
+
+		scope := sc.scope
+		table := sc.table
+		n := len(scope.Stmts)
+		nv := len(table.Vars)
+		na := len(table.Type_aliases)
+
+		prev := sc.s.push_allows(directives)
+		directives = nil
+
+		sc.check_node(node)
+
+		// Remember tokens and suppressions of statements for control flow analysis.
+		token := get_node_token(node)
+		for i := n; i < len(scope.Stmts); i++ {
+			sc.tokens[scope.Stmts[i]] = token
+			sc.allows[scope.Stmts[i]] = sc.s.allows
+		}
+
+		// Remember suppressions of declarations for unused checks.
+		for _, v := range table.Vars[nv:] {
+			sc.allows[v] = sc.s.allows
+		}
+		for _, a := range table.Type_aliases[na:] {
+			sc.allows[a] = sc.s.allows
+		}
+
+		sc.s.allows = prev
 	}
+
+	// Directives without statement are built to report them.
+	_ = sc.s.get_allows(directives)
 }
 
 func st_is_def(st St) bool {
@@ -1492,7 +1625,7 @@ func (sc *_ScopeChecker) check_gotos() {
 func (sc *_ScopeChecker) check_labels() {
 	for _, l := range *sc.labels {
 		if !l.used {
-			sc.s.push_err(l.token, "declared_but_not_used", l.label.Ident)
+			sc.s.push_warn_with(l.allows, build.WARN_UNUSED, l.token, "declared_but_not_used", l.label.Ident)
 		}
 	}
 }
@@ -1500,7 +1633,9 @@ func (sc *_ScopeChecker) check_labels() {
 func (sc *_ScopeChecker) check_vars() {
 	for _, v := range sc.table.Vars {
 		if !v.Used && !lex.Is_ignore_ident(v.Ident) && !lex.Is_anon_ident(v.Ident) && v.Ident != lex.KND_SELF {
-			sc.s.push_err(v.Token, "declared_but_not_used", v.Ident)
+			if sc.push_warn_of(v, build.WARN_UNUSED, v.Token, "declared_but_not_used", v.Ident) {
+				sc.s.push_warn_fix(build.WARN_UNUSED, remove_var_fix(v)...)
+			}
 		}
 	}
 }
@@ -1508,11 +1643,20 @@ func (sc *_ScopeChecker) check_vars() {
 func (sc *_ScopeChecker) check_aliases() {
 	for _, a := range sc.table.Type_aliases {
 		if !a.Used && !lex.Is_ignore_ident(a.Ident) && !lex.Is_anon_ident(a.Ident) {
-			sc.s.push_err(a.Token, "declared_but_not_used", a.Ident)
+			sc.push_warn_of(a, build.WARN_UNUSED, a.Token, "declared_but_not_used", a.Ident)
 		}
 	}
 }
 
+// Same as push_warn, but uses suppressions of node if remembered.
+func (sc *_ScopeChecker) push_warn_of(node any, class string, token lex.Token, key string, args ...any) bool {
+	allows, ok := sc.allows[node]
+	if !ok {
+		return sc.s.push_warn(class, token, key, args...)
+	}
+	return sc.s.push_warn_with(allows, class, token, key, args...)
+}
+
 // Checks scope tree.
 func (sc *_ScopeChecker) check(tree *ast.ScopeTree, s *Scope) {
 	s.Deferred = tree.Deferred
@@ -1521,6 +1665,8 @@ func (sc *_ScopeChecker) check(tree *ast.ScopeTree, s *Scope) {
 	sc.tree = tree
 	sc.scope = s
 
+	sc.allows[s] = sc.s.allows
+
 	sc.check_tree()
 
 	sc.check_vars()
@@ -1529,6 +1675,7 @@ func (sc *_ScopeChecker) check(tree *ast.ScopeTree, s *Scope) {
 	if sc.is_root() {
 		sc.check_gotos()
 		sc.check_labels()
+		sc.check_flow(s)
 	}
 }
 
@@ -1537,6 +1684,8 @@ func (sc *_ScopeChecker) new_child_checker() *_ScopeChecker {
 	base.parent = sc
 	base.labels = sc.labels
 	base.gotos = sc.gotos
+	base.tokens = sc.tokens
+	base.allows = sc.allows
 	base.child_index = sc.child_index + 1
 	return base
 }
@@ -1553,6 +1702,8 @@ func new_scope_checker(s *_Sema, owner *FnIns) *_ScopeChecker {
 	base := new_scope_checker_base(s, owner)
 	base.labels = new([]*_ScopeLabel)
 	base.gotos = new([]*_ScopeGoto)
+	base.tokens = map[any]lex.Token{}
+	base.allows = map[any][]*_Allow{}
 	return base
 }
 
@@ -1623,6 +1774,7 @@ func check_mut(s *_Sema, left *Data, right *Data, error_token lex.Token) (ok boo
 	switch {
 	case !left.Mutable:
 		s.push_err(error_token, "assignment_to_non_mut")
+		s.push_err_fix(add_mut_fix(left, error_token)...)
 		return false
 
 	case right != nil && !right.Mutable && is_mut(right.Kind):
@@ -5,6 +5,7 @@
 package sema
 
 import (
+	"strconv"
 	"strings"
 	"unsafe"
 
@@ -23,11 +24,42 @@ func compiler_err(token lex.Token, key string, args ...any) build.Log {
 		Type:   build.ERR,
 		Row:    token.Row,
 		Column: token.Column,
+		Length: len(token.Kind),
 		Path:   token.File.Path(),
 		Text:   build.Errorf(key, args...),
+		Code:   build.Code(key),
 	}
 }
 
+func compiler_note(token lex.Token, key string, args ...any) build.Log {
+	return build.Log{
+		Type:   build.NOTE,
+		Row:    token.Row,
+		Column: token.Column,
+		Length: len(token.Kind),
+		Path:   token.File.Path(),
+		Text:   build.Warnf(key, args...),
+	}
+}
+
+// Returns message of deprecated directive.
+// Reports whether directives have deprecated directive.
+func get_deprecated(directives []*ast.Directive) (string, bool) {
+	for _, d := range directives {
+		if d.Tag != build.DIRECTIVE_DEPRECATED {
+			continue
+		}
+
+		msg := strings.TrimSpace(strings.Join(d.Args, " "))
+		unquoted, err := strconv.Unquote(msg)
+		if err == nil {
+			msg = unquoted
+		}
+		return msg, true
+	}
+	return "", false
+}
+
 func imp_is_lookupable(i *ImportInfo, ident string) bool {
 	if i.Cpp_linked {
 		return false
@@ -171,9 +203,16 @@ func find_file(files []*SymbolTable, handler *lex.File) *SymbolTable {
 // Semantic analyzer for tables.
 // Accepts tables as files of package.
 type _Sema struct {
-	errors []build.Log
-	files  []*SymbolTable // Package files.
-	file   *SymbolTable   // Current package file.
+	errors   []build.Log
+	warnings *[]build.Log         // Shared by all packages.
+	exports  map[string]lex.Token // Export identifiers of functions, shared by all packages.
+	files    []*SymbolTable       // Package files.
+	file     *SymbolTable         // Current package file.
+	imported bool                 // Warnings and vet checks are not reported for imported packages.
+
+	allows     []*_Allow                    // Current suppressions of allow directives.
+	allow_map  map[*ast.Directive][]*_Allow // Suppressions by allow directives.
+	allow_list []*_Allow                    // All suppressions in order of directives.
 }
 
 func (s *_Sema) set_current_file(f *SymbolTable) { s.file = f }
@@ -182,6 +221,127 @@ func (s *_Sema) push_err(token lex.Token, key string, args ...any) {
 	s.errors = append(s.errors, compiler_err(token, key, args...))
 }
 
+// Attaches note to log.
+// Note is not attached if token has not position.
+func attach_note(log *build.Log, token lex.Token, key string, args ...any) {
+	if token.File != nil {
+		log.Notes = append(log.Notes, compiler_note(token, key, args...))
+	}
+}
+
+// Attaches note to last pushed error.
+func (s *_Sema) push_err_note(token lex.Token, key string, args ...any) {
+	if len(s.errors) > 0 {
+		attach_note(&s.errors[len(s.errors)-1], token, key, args...)
+	}
+}
+
+// Reports whether token a comes before token b in package.
+// Tokens of different files are ordered by files of package.
+func (s *_Sema) is_before(a lex.Token, b lex.Token) bool {
+	if a.File != b.File {
+		for _, f := range s.files {
+			switch f.File {
+			case a.File:
+				return true
+
+			case b.File:
+				return false
+			}
+		}
+		return false
+	}
+	return a.Row < b.Row || (a.Row == b.Row && a.Column < b.Column)
+}
+
+// Pushes duplicated identifier error with note of previous declaration.
+// Error is pushed at later declaration, note at earlier declaration.
+func (s *_Sema) push_duplicated_ident(token lex.Token, ident string, prev lex.Token) {
+	if s.is_before(token, prev) {
+		token, prev = prev, token
+	}
+	s.push_err(token, "duplicated_ident", ident)
+	s.push_err_note(prev, "previous_declaration", ident)
+}
+
+// Pushes log of warning class by level of class.
+// Reports whether log pushed, duplicated logs are not pushed.
+// Warnings and vet checks of imported packages are not pushed,
+// but errors are always pushed.
+func (s *_Sema) push_warn(class string, token lex.Token, key string, args ...any) bool {
+	log := build.Log{
+		Row:    token.Row,
+		Column: token.Column,
+		Length: len(token.Kind),
+		Path:   token.File.Path(),
+		Text:   build.Warnf(key, args...),
+		Class:  class,
+		Code:   build.Code(key),
+	}
+
+	logs := s.warnings
+	switch build.Warn_level(class) {
+	case build.WARN_LEVEL_OFF:
+		return false
+
+	case build.WARN_LEVEL_WARN:
+		log.Type = build.WARN
+
+	default:
+		log.Type = build.ERR
+		logs = &s.errors
+	}
+
+	if s.imported && (log.Type == build.WARN || build.Is_vet_check(class)) {
+		return false
+	}
+
+	if s.is_allowed(class) {
+		return false
+	}
+
+	for _, l := range *logs {
+		if l.Is_same(&log) {
+			return false
+		}
+	}
+	*logs = append(*logs, log)
+	return true
+}
+
+// Attaches note to last pushed log of warning class.
+func (s *_Sema) push_warn_note(class string, token lex.Token, key string, args ...any) {
+	logs := s.warnings
+	if build.Warn_level(class) != build.WARN_LEVEL_WARN {
+		logs = &s.errors
+	}
+
+	if len(*logs) > 0 {
+		attach_note(&(*logs)[len(*logs)-1], token, key, args...)
+	}
+}
+
+// Warns if directives have deprecated directive.
+// Decl is the token of deprecated definition.
+func (s *_Sema) check_deprecated(directives []*ast.Directive, ident string, decl lex.Token, token lex.Token) {
+	const CLASS = build.WARN_DEPRECATED
+
+	msg, ok := get_deprecated(directives)
+	if !ok {
+		return
+	}
+
+	if msg == "" {
+		ok = s.push_warn(CLASS, token, "deprecated", ident)
+	} else {
+		ok = s.push_warn(CLASS, token, "deprecated_with_message", ident, msg)
+	}
+
+	if ok && decl.File != nil {
+		s.push_warn_note(CLASS, decl, "declared_here", ident)
+	}
+}
+
 // Reports whether define is accessible in the current package.
 func (s *_Sema) is_accessible_define(public bool, token lex.Token) bool {
 	return public || token.File == nil || s.file.File.Dir() == token.File.Dir()
@@ -190,21 +350,31 @@ func (s *_Sema) is_accessible_define(public bool, token lex.Token) bool {
 // Reports this identifier duplicated in package's global scope.
 // The "self" parameter represents address of exception identifier.
 // If founded identifier address equals to self, will be skipped.
-func (s *_Sema) is_duplicated_ident(itself uintptr, ident string, cpp_linked bool) bool {
+// Returns token of duplicated define as first result.
+func (s *_Sema) find_duplicated_ident(itself uintptr, ident string, cpp_linked bool) (lex.Token, bool) {
 	for _, f := range s.files {
-		if f.is_duplicated_ident(itself, ident, cpp_linked) {
-			return true
+		token, ok := f.find_duplicated_ident(itself, ident, cpp_linked)
+		if ok {
+			return token, true
 		}
 
 		for _, imp := range f.Imports {
 			for _, selected := range imp.Selected {
 				if selected.Kind == ident {
-					return true
+					return selected, true
 				}
 			}
 		}
 	}
-	return false
+	return lex.Token{}, false
+}
+
+// Pushes error if identifier duplicated in package's global scope.
+func (s *_Sema) check_duplicated_ident(itself uintptr, token lex.Token, ident string, cpp_linked bool) {
+	prev, ok := s.find_duplicated_ident(itself, ident, cpp_linked)
+	if ok {
+		s.push_duplicated_ident(token, ident, prev)
+	}
 }
 
 func (s *_Sema) check_generic_quantity(required int, given int, error_token lex.Token) (ok bool) {
@@ -269,6 +439,7 @@ func (s *_Sema) Find_var(ident string, cpp_linked bool) *Var {
 		}
 		v := imp.Find_var(ident, cpp_linked)
 		if v != nil && s.is_accessible_define(v.Public, v.Token) {
+			imp.use(ident)
 			return v
 		}
 	}
@@ -296,6 +467,7 @@ func (s *_Sema) Find_type_alias(ident string, cpp_linked bool) *TypeAlias {
 		}
 		ta := imp.Find_type_alias(ident, cpp_linked)
 		if ta != nil && s.is_accessible_define(ta.Public, ta.Token) {
+			imp.use(ident)
 			return ta
 		}
 	}
@@ -323,6 +495,7 @@ func (s *_Sema) Find_struct(ident string, cpp_linked bool) *Struct {
 		}
 		strct := imp.Find_struct(ident, cpp_linked)
 		if strct != nil && s.is_accessible_define(strct.Public, strct.Token) {
+			imp.use(ident)
 			return strct
 		}
 	}
@@ -350,6 +523,7 @@ func (s *_Sema) Find_fn(ident string, cpp_linked bool) *Fn {
 		}
 		f := imp.Find_fn(ident, cpp_linked)
 		if f != nil && s.is_accessible_define(f.Public, f.Token) {
+			imp.use(ident)
 			return f
 		}
 	}
@@ -377,6 +551,7 @@ func (s *_Sema) Find_trait(ident string) *Trait {
 		}
 		t := imp.Find_trait(ident)
 		if t != nil && s.is_accessible_define(t.Public, t.Token) {
+			imp.use(ident)
 			return t
 		}
 	}
@@ -404,6 +579,7 @@ func (s *_Sema) Find_enum(ident string) *Enum {
 		}
 		e := imp.Find_enum(ident)
 		if e != nil && s.is_accessible_define(e.Public, e.Token) {
+			imp.use(ident)
 			return e
 		}
 	}
@@ -411,19 +587,23 @@ func (s *_Sema) Find_enum(ident string) *Enum {
 	return nil
 }
 
-func (s *_Sema) is_duplicated_import_selection(self uintptr, ident string) bool {
+// Returns token of previous selection of identifier.
+// Reports whether identifier is selected by previous imports.
+func (s *_Sema) find_duplicated_import_selection(self uintptr, ident string) (lex.Token, bool) {
 	for _, imp := range s.file.Imports {
 		if _uintptr(imp) == self {
 			// Don't scan trailing imports.
 			break
 		}
 
-		if imp.exist_ident(ident) {
-			return true
+		for _, selected := range imp.Selected {
+			if selected.Kind == ident {
+				return selected, true
+			}
 		}
 	}
 
-	return false
+	return lex.Token{}, false
 }
 
 func (s *_Sema) check_import_selections(imp *ImportInfo) {
@@ -450,8 +630,9 @@ func (s *_Sema) check_import_selections(imp *ImportInfo) {
 			continue
 		}
 
-		if s.is_duplicated_import_selection(_uintptr(imp), ident.Kind) {
-			s.push_err(ident, "duplicated_ident", ident.Kind)
+		prev, ok := s.find_duplicated_import_selection(_uintptr(imp), ident.Kind)
+		if ok {
+			s.push_duplicated_ident(ident, ident.Kind, prev)
 			continue
 		}
 
@@ -498,7 +679,7 @@ func (s *_Sema) check_import_selections(imp *ImportInfo) {
 			}
 
 		default:
-			s.push_err(ident, "ident_not_exist", ident.Kind)
+			s.push_suggested_err(ident, ident.Kind, imp.get_public_idents(), "ident_not_exist", ident.Kind)
 			continue
 		}
 
@@ -514,7 +695,11 @@ func (s *_Sema) check_import(imp *ImportInfo) bool {
 		return true
 	}
 
-	sema := _Sema{}
+	sema := _Sema{
+		warnings: s.warnings,
+		exports:  s.exports,
+		imported: true,
+	}
 	sema.check(imp.Package.Files)
 	if len(sema.errors) > 0 {
 		s.errors = append(s.errors, sema.errors...)
@@ -812,6 +997,26 @@ func (s *_Sema) check_validity_for_init_expr(left_mut bool, left_kind *TypeKind,
 	_ = atc.check_validity()
 }
 
+// Checks generic cpp-linked type alias declaration.
+// Kind is not built because built for each use with generics.
+func (s *_Sema) check_generic_type_alias_decl(ta *TypeAlias, l Lookup) (ok bool) {
+	if !ta.Cpp_linked {
+		s.push_err(ta.Token, "generic_type_alias_not_cpp_linked")
+		return false
+	}
+
+	if !s.check_decl_generics(ta.Generics) {
+		return false
+	}
+
+	tc := _TypeChecker{
+		s:               s,
+		lookup:          l,
+		ignore_generics: ta.Generics,
+	}
+	return tc.check_decl(ta.Kind.Decl) != nil
+}
+
 func (s *_Sema) check_type_alias_decl_kind(ta *TypeAlias, l Lookup) (ok bool) {
 	old := s.file
 	defer s.set_current_file(old)
@@ -820,6 +1025,10 @@ func (s *_Sema) check_type_alias_decl_kind(ta *TypeAlias, l Lookup) (ok bool) {
 		s.set_current_file(file)
 	}
 
+	if len(ta.Generics) > 0 {
+		return s.check_generic_type_alias_decl(ta, l)
+	}
+
 	ok = s.check_type_with_refers(ta.Kind, l, &_Referencer{
 		ident: ta.Ident,
 		owner: ta,
@@ -841,9 +1050,7 @@ func (s *_Sema) check_type_alias_decl(ta *TypeAlias, l Lookup) {
 
 // Checks type alias declaration with duplicated identifiers.
 func (s *_Sema) check_type_alias_decl_dup(ta *TypeAlias) {
-	if s.is_duplicated_ident(_uintptr(ta), ta.Ident, ta.Cpp_linked) {
-		s.push_err(ta.Token, "duplicated_ident", ta.Ident)
-	}
+	s.check_duplicated_ident(_uintptr(ta), ta.Token, ta.Ident, ta.Cpp_linked)
 	s.check_type_alias_decl_kind(ta, s)
 }
 
@@ -869,7 +1076,7 @@ func (s *_Sema) check_enum_items_dup(e *Enum) {
 				if item == citem {
 					break
 				} else if item.Ident == citem.Ident {
-					s.push_err(item.Token, "duplicated_ident", item.Ident)
+					s.push_duplicated_ident(item.Token, item.Ident, citem.Token)
 					break
 				}
 			}
@@ -935,10 +1142,13 @@ func (s *_Sema) check_enum_items_int(e *Enum) {
 }
 
 func (s *_Sema) check_enum_decl(e *Enum) {
+	prev := s.enter_allows(e.Directives)
+	defer func() { s.allows = prev }()
+
 	if lex.Is_ignore_ident(e.Ident) {
 		s.push_err(e.Token, "ignore_ident")
-	} else if s.is_duplicated_ident(_uintptr(e), e.Ident, false) {
-		s.push_err(e.Token, "duplicated_ident", e.Ident)
+	} else {
+		s.check_duplicated_ident(_uintptr(e), e.Token, e.Ident, false)
 	}
 
 	if len(e.Items) == 0 {
@@ -1011,7 +1221,7 @@ func (s *_Sema) check_decl_generics(generics []*ast.GenericDecl) (ok bool) {
 				break duplication_lookup
 
 			case g.Ident == ct.Ident:
-				s.push_err(g.Token, "duplicated_ident", g.Ident)
+				s.push_duplicated_ident(g.Token, g.Ident, ct.Token)
 				ok = false
 				break duplication_lookup
 			}
@@ -1028,7 +1238,7 @@ check:
 		for _, g := range f.Generics {
 			if p.Ident == g.Ident {
 				ok = false
-				s.push_err(p.Token, "duplicated_ident", p.Ident)
+				s.push_duplicated_ident(p.Token, p.Ident, g.Token)
 				continue check
 			}
 		}
@@ -1046,7 +1256,7 @@ check:
 
 			case p.Ident == jp.Ident:
 				ok = false
-				s.push_err(p.Token, "duplicated_ident", p.Ident)
+				s.push_duplicated_ident(p.Token, p.Ident, jp.Token)
 				continue check
 			}
 		}
@@ -1067,9 +1277,12 @@ func (s *_Sema) check_fn_decl_result_dup(f *Fn) (ok bool) {
 			continue // Skip anonymous return variables.
 		}
 
+		prev := lex.Token{}
+
 		// Lookup in generics.
 		for _, g := range f.Generics {
 			if v.Kind == g.Ident {
+				prev = g.Token
 				goto exist
 			}
 		}
@@ -1077,6 +1290,7 @@ func (s *_Sema) check_fn_decl_result_dup(f *Fn) (ok bool) {
 		// Lookup in parameters.
 		for _, p := range f.Params {
 			if v.Kind == p.Ident {
+				prev = p.Token
 				goto exist
 			}
 		}
@@ -1090,12 +1304,13 @@ func (s *_Sema) check_fn_decl_result_dup(f *Fn) (ok bool) {
 				break itself_lookup
 
 			case jv.Kind == v.Kind:
+				prev = jv
 				goto exist
 			}
 		}
 		continue
 	exist:
-		s.push_err(v, "duplicated_ident", v.Kind)
+		s.push_duplicated_ident(v, v.Kind, prev)
 		ok = false
 	}
 
@@ -1180,7 +1395,7 @@ func (s *_Sema) check_trait_decl_methods(t *Trait) {
 				break duplicate_lookup
 
 			case f.Ident == jf.Ident:
-				s.push_err(f.Token, "duplicated_ident", f.Ident)
+				s.push_duplicated_ident(f.Token, f.Ident, jf.Token)
 				break duplicate_lookup
 			}
 		}
@@ -1188,10 +1403,13 @@ func (s *_Sema) check_trait_decl_methods(t *Trait) {
 }
 
 func (s *_Sema) check_trait_decl(t *Trait) {
+	prev := s.enter_allows(t.Directives)
+	defer func() { s.allows = prev }()
+
 	if lex.Is_ignore_ident(t.Ident) {
 		s.push_err(t.Token, "ignore_ident")
-	} else if s.is_duplicated_ident(_uintptr(t), t.Ident, false) {
-		s.push_err(t.Token, "duplicated_ident", t.Ident)
+	} else {
+		s.check_duplicated_ident(_uintptr(t), t.Token, t.Ident, false)
 	}
 
 	s.check_trait_decl_methods(t)
@@ -1241,6 +1459,15 @@ func (s *_Sema) impl_to_struct(dest *Struct, ipl *Impl) (ok bool) {
 			}
 		}
 
+		if f.Export_ident() != "" {
+			s.push_err(f.Token, "export_method")
+			ok = false
+		}
+
+		if !s.check_fn_attributes(f) {
+			ok = false
+		}
+
 		f.sema = s
 		f.Owner = dest
 		dest.Methods = append(dest.Methods, f)
@@ -1341,16 +1568,32 @@ func (s *_Sema) check_var_decl(decl *Var, l Lookup) {
 // Checks variable declaration.
 // Checks duplicated identifiers by Sema.
 func (s *_Sema) check_var_decl_dup(decl *Var) {
-	if s.is_duplicated_ident(_uintptr(decl), decl.Ident, decl.Cpp_linked) {
-		s.push_err(decl.Token, "duplicated_ident", decl.Ident)
-	}
+	s.check_duplicated_ident(_uintptr(decl), decl.Token, decl.Ident, decl.Cpp_linked)
 	s.check_var_decl(decl, s)
 }
 
+// Checks storage directives of global variable.
+func (s *_Sema) check_global_directives(decl *Var) {
+	if !decl.Is_thread_local() {
+		return
+	}
+
+	switch {
+	case decl.Constant:
+		s.push_err(decl.Token, "thread_local_const", decl.Ident)
+
+	case decl.Cpp_linked:
+		s.push_err(decl.Token, "thread_local_cpp_linked", decl.Ident)
+	}
+}
+
 // Checks current package file's global variable declarations.
 func (s *_Sema) check_global_decls() (ok bool) {
 	for _, decl := range s.file.Vars {
+		prev := s.enter_allows(decl.Directives)
 		s.check_var_decl_dup(decl)
+		s.check_global_directives(decl)
+		s.allows = prev
 
 		// Break checking if type alias has error.
 		if len(s.errors) > 0 {
@@ -1360,7 +1603,10 @@ func (s *_Sema) check_global_decls() (ok bool) {
 	return true
 }
 
+// Reports whether structure implements all methods of trait.
+// Reports errors for each method that is not implemented.
 func (s *_Sema) check_struct_trait_impl(strct *Struct, trt *Trait) (ok bool) {
+	ok = true
 	for _, tf := range trt.Methods {
 		exist := false
 		sf := strct.Find_method(tf.Ident)
@@ -1373,6 +1619,10 @@ func (s *_Sema) check_struct_trait_impl(strct *Struct, trt *Trait) (ok bool) {
 			ins := tf.instance_force()
 			s.build_fn_non_generic_type_kinds(ins, false)
 			s.push_err(strct.Token, "not_impl_trait_def", trt.Ident, ins.To_str())
+			s.push_err_note(tf.Token, "required_by_trait", tf.Ident, trt.Ident)
+			if sf != nil {
+				s.push_err_note(sf.Token, "implemented_here", sf.Ident)
+			}
 			ok = false
 		}
 	}
@@ -1409,7 +1659,7 @@ func (s *_Sema) check_struct_fields(st *Struct) (ok bool) {
 			if f == cf {
 				break
 			} else if f.Ident == cf.Ident {
-				s.push_err(f.Token, "duplicated_ident", f.Ident)
+				s.push_duplicated_ident(f.Token, f.Ident, cf.Token)
 				ok = false
 			}
 		}
@@ -1426,11 +1676,166 @@ func (s *_Sema) check_struct_fields(st *Struct) (ok bool) {
 	return ok
 }
 
+// Reports whether type is compatible with C layout of structures.
+// Pointers are always compatible because layout not depends on element type.
+func is_c_layout_compatible_type(t *TypeKind) bool {
+	switch {
+	case t == nil:
+		return false
+
+	case t.Cpp_linked || t.Ptr() != nil:
+		return true
+
+	case t.Strct() != nil:
+		return t.Strct().Decl.Is_repr_c()
+
+	case t.Enm() != nil:
+		return is_c_layout_compatible_type(t.Enm().Kind.Kind)
+
+	case t.Arr() != nil:
+		return is_c_layout_compatible_type(t.Arr().Elem)
+
+	case t.Fnc() != nil:
+		return t.Fnc().Decl.C_fnptr
+
+	default:
+		return is_c_compatible_type(t)
+	}
+}
+
+// Reports whether type is always aligned to one byte.
+func is_byte_aligned_type(t *TypeKind) bool {
+	switch {
+	case t.Prim() != nil:
+		prim := t.Prim()
+		return prim.Is_u8() || prim.Is_i8() || prim.Is_bool()
+
+	case t.Arr() != nil:
+		return is_byte_aligned_type(t.Arr().Elem)
+
+	case t.Strct() != nil:
+		s := t.Strct().Decl
+		return s.Is_repr_c() && s.Is_packed() && s.Align() == 0
+
+	default:
+		return false
+	}
+}
+
+// Reports whether type is compatible with packed structures.
+// Compiler does not pack arrays and structures because of constructors,
+// so these types must be aligned to one byte.
+func is_packable_type(t *TypeKind) bool {
+	switch {
+	case t == nil:
+		return false
+
+	case t.Cpp_linked:
+		return true
+
+	case t.Arr() != nil || t.Strct() != nil:
+		return is_byte_aligned_type(t)
+
+	case t.Enm() != nil:
+		return is_packable_type(t.Enm().Kind.Kind)
+
+	default:
+		return is_c_layout_compatible_type(t)
+	}
+}
+
+// Checks arguments of layout directives of structure.
+func (s *_Sema) check_struct_layout_directives(st *Struct) (ok bool) {
+	ok = true
+	for _, d := range st.Directives {
+		switch d.Tag {
+		case build.DIRECTIVE_REPR:
+			if !st.Is_repr_c() {
+				s.push_err(d.Token, "invalid_repr_directive", strings.Join(d.Args, " "))
+				ok = false
+			}
+
+		case build.DIRECTIVE_ALIGN:
+			align := st.Align()
+			if align == 0 || align&(align-1) != 0 {
+				s.push_err(d.Token, "invalid_align_directive", strings.Join(d.Args, " "))
+				ok = false
+			}
+			fallthrough
+
+		case build.DIRECTIVE_PACKED:
+			if !st.Cpp_linked && !st.Is_repr_c() {
+				s.push_err(d.Token, "layout_directive_without_repr_c", d.Tag)
+				ok = false
+			}
+
+		default:
+			continue
+		}
+
+		if st.Cpp_linked {
+			s.push_err(d.Token, "layout_directive_for_cpp_linked")
+			ok = false
+		}
+	}
+	return ok
+}
+
+// Checks layout of repr C and packed structure.
+func (s *_Sema) check_struct_layout(st *Struct) (ok bool) {
+	ok = s.check_struct_layout_directives(st)
+	repr_c := st.Is_repr_c()
+	packed := st.Is_packed()
+	if !repr_c && !packed {
+		return ok
+	}
+
+	if len(st.Generics) > 0 {
+		s.push_err(st.Token, "layout_directive_for_generic_struct")
+		return false
+	}
+
+	if repr_c {
+		if len(st.Implements) > 0 {
+			s.push_err(st.Token, "repr_c_implements_trait", st.Ident)
+			ok = false
+		}
+
+		for _, f := range st.Methods {
+			if len(f.Params) > 0 && f.Params[0].Is_self() && f.Params[0].Is_ref() {
+				s.push_err(f.Token, "repr_c_ref_self", f.Ident)
+				ok = false
+			}
+		}
+	}
+
+	for _, f := range st.Fields {
+		k := f.Kind.Kind
+		switch {
+		case packed && k.Ref() != nil:
+			s.push_err(f.Token, "packed_ref_field", f.Ident)
+			ok = false
+
+		case repr_c && !is_c_layout_compatible_type(k):
+			s.push_err(f.Token, "repr_c_incompatible_field", f.Ident, k.To_str())
+			ok = false
+
+		case packed && !is_packable_type(k):
+			s.push_err(f.Token, "packed_incompatible_field", f.Ident, k.To_str())
+			ok = false
+		}
+	}
+	return ok
+}
+
 func (s *_Sema) check_struct_decl(strct *Struct) {
+	prev := s.enter_allows(strct.Directives)
+	defer func() { s.allows = prev }()
+
 	if lex.Is_ignore_ident(strct.Ident) {
 		s.push_err(strct.Token, "ignore_ident")
-	} else if s.is_duplicated_ident(_uintptr(strct), strct.Ident, strct.Cpp_linked) {
-		s.push_err(strct.Token, "duplicated_ident", strct.Ident)
+	} else {
+		s.check_duplicated_ident(_uintptr(strct), strct.Token, strct.Ident, strct.Cpp_linked)
 	}
 
 	strct.sema = s
@@ -1443,6 +1848,9 @@ func (s *_Sema) check_struct_decl(strct *Struct) {
 
 	case !s.check_struct_impls(strct):
 		return
+
+	case !s.check_struct_layout(strct):
+		return
 	}
 }
 
@@ -1460,13 +1868,121 @@ func (s *_Sema) check_struct_decls() (ok bool) {
 	return true
 }
 
+// Reports whether identifier is valid symbol identifier for C.
+func is_valid_c_ident(ident string) bool {
+	if !lex.Is_ident_rune(ident) {
+		return false
+	}
+
+	for _, r := range ident {
+		if r != '_' && !lex.Is_letter(r) && (r > 0xFF || !lex.Is_decimal(byte(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Registers export identifier of function for all packages.
+// Returns token of previous function if identifier is already used.
+func (s *_Sema) push_export(f *Fn, ident string) (lex.Token, bool) {
+	prev, ok := s.exports[ident]
+	if ok {
+		return prev, true
+	}
+	s.exports[ident] = f.Token
+	return lex.Token{}, false
+}
+
+// Checks declaration of exported function.
+func (s *_Sema) check_export_fn_decl(f *Fn) {
+	ident := f.Export_ident()
+	switch {
+	case f.Cpp_linked:
+		s.push_err(f.Token, "export_cpp_linked")
+
+	case len(f.Generics) > 0:
+		s.push_err(f.Token, "export_generic_fn")
+
+	case f.Is_entry_point() || f.Ident == build.INIT_FN:
+		s.push_err(f.Token, "export_special_fn")
+
+	case !is_valid_c_ident(ident):
+		s.push_err(f.Token, "export_invalid_ident", ident)
+
+	default:
+		prev, ok := s.push_export(f, ident)
+		if ok {
+			s.push_err(f.Token, "export_duplicated_ident", ident)
+			s.push_err_note(prev, "previous_declaration", ident)
+		}
+	}
+
+	for _, p := range f.Params {
+		if p.Variadic {
+			s.push_err(p.Token, "export_variadic")
+		}
+	}
+}
+
+// Pairs of function attribute directives that cannot be used together.
+var conflicting_fn_attributes = [...][2]string{
+	{build.DIRECTIVE_INLINE, build.DIRECTIVE_NOINLINE},
+	{build.DIRECTIVE_COLD, build.DIRECTIVE_HOT},
+}
+
+// Returns directive of function by tag.
+// Returns nil if function has not directive.
+func find_fn_directive(f *Fn, tag string) *ast.Directive {
+	for _, d := range f.Directives {
+		if d.Tag == tag {
+			return d
+		}
+	}
+	return nil
+}
+
+// Checks function attribute directives of function.
+func (s *_Sema) check_fn_attributes(f *Fn) (ok bool) {
+	ok = true
+	for _, d := range f.Directives {
+		if !build.Is_fn_attribute_directive(d.Tag) {
+			continue
+		}
+
+		if f.Cpp_linked {
+			s.push_err(d.Token, "fn_attribute_for_cpp_linked", d.Tag)
+			ok = false
+		} else if f.Is_entry_point() && d.Tag == build.DIRECTIVE_INLINE {
+			s.push_err(d.Token, "inline_entry_point")
+			ok = false
+		}
+	}
+
+	for _, pair := range conflicting_fn_attributes {
+		d := find_fn_directive(f, pair[1])
+		if d != nil && find_fn_directive(f, pair[0]) != nil {
+			s.push_err(d.Token, "conflicting_fn_attributes", pair[0], pair[1])
+			ok = false
+		}
+	}
+	return ok
+}
+
 func (s *_Sema) check_fn_decl(f *Fn) {
+	prev := s.enter_allows(f.Directives)
+	defer func() { s.allows = prev }()
+
 	if lex.Is_ignore_ident(f.Ident) {
 		s.push_err(f.Token, "ignore_ident")
-	} else if s.is_duplicated_ident(_uintptr(f), f.Ident, f.Cpp_linked) {
-		s.push_err(f.Token, "duplicated_ident", f.Ident)
+	} else {
+		s.check_duplicated_ident(_uintptr(f), f.Token, f.Ident, f.Cpp_linked)
 	}
 
+	if f.Export_ident() != "" {
+		s.check_export_fn_decl(f)
+	}
+	_ = s.check_fn_attributes(f)
+
 	f.sema = s
 	_ = s.check_fn_decl_prototype(f)
 }
@@ -1505,6 +2021,46 @@ func (s *_Sema) check_passes() (ok bool) {
 	return
 }
 
+// Reports whether library name is valid for link directive.
+// Library names are passed to pkg-config and linker,
+// so only file name bytes allowed and must not start with dash.
+func is_valid_link_lib(lib string) bool {
+	if lib == "" || lib[0] == '-' {
+		return false
+	}
+	for _, b := range []byte(lib) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9':
+		case b == '_' || b == '-' || b == '.' || b == '+':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (s *_Sema) check_directive_link(link Link) (ok bool) {
+	if link.Lib == "" {
+		s.push_err(link.Token, "link_directive_missing_lib")
+		return false
+	}
+
+	if !is_valid_link_lib(link.Lib) {
+		s.push_err(link.Token, "link_directive_invalid_lib", link.Lib)
+		return false
+	}
+
+	return true
+}
+
+func (s *_Sema) check_links() (ok bool) {
+	ok = true
+	for _, link := range s.file.Links {
+		ok = s.check_directive_link(link) && ok
+	}
+	return
+}
+
 // Checks all declarations of current package file.
 // Reports whether checking is success.
 func (s *_Sema) check_file_decls() (ok bool) {
@@ -1512,6 +2068,9 @@ func (s *_Sema) check_file_decls() (ok bool) {
 	case !s.check_passes():
 		return false
 
+	case !s.check_links():
+		return false
+
 	case !s.check_type_alias_decls():
 		return false
 
@@ -1708,6 +2267,7 @@ func (s *_Sema) check_var(v *Var) {
 		}
 
 		s.check_assign_type(v.Kind.Kind, v.Value.Data, v.Value.Expr.Token, false)
+		s.push_cast_fix(v.Kind.Kind, v.Value.Data, v.Value.Expr, v.Value.Expr.Token)
 	}
 
 	s.check_validity_for_init_expr(v.Mutable, v.Kind.Kind, v.Value.Data, v.Value.Expr.Token)
@@ -1730,16 +2290,23 @@ func (s *_Sema) check_type_var(decl *Var, l Lookup) {
 	s.check_var(decl)
 }
 
+// Checks type of global variable with suppressions of variable.
+func (s *_Sema) check_type_global_var(decl *Var) {
+	prev := s.enter_allows(decl.Directives)
+	s.check_type_var(decl, s)
+	s.allows = prev
+}
+
 // Checks types of current package file's global variables.
 func (s *_Sema) check_global_types() {
 	for _, decl := range s.file.Vars {
-		s.check_type_var(decl, s)
+		s.check_type_global_var(decl)
 	}
 
 	// Re-check depended.
 	for _, decl := range s.file.Vars {
 		if decl.Is_initialized() && len(decl.Depends) > 0 {
-			s.check_type_var(decl, s)
+			s.check_type_global_var(decl)
 		}
 	}
 }
@@ -1868,6 +2435,12 @@ func __has_ret(s *Scope) (ok bool, falled bool, breaked bool) {
 		case *RetSt:
 			return true, falled, breaked
 
+		case *Data:
+			// Panic calls never returns.
+			if is_panic_call(st) {
+				return true, falled, breaked
+			}
+
 		case *Scope:
 			ok := has_ret(st.(*Scope))
 			if ok {
@@ -1914,6 +2487,7 @@ func (s *_Sema) check_rets(f *FnIns) {
 	ok := has_ret(f.Scope)
 	if !ok {
 		s.push_err(f.Decl.Token, "missing_ret")
+		s.push_err_fix(add_ret_fix(f)...)
 	}
 }
 
@@ -1959,6 +2533,17 @@ func (s *_Sema) check_fn_ins(f *FnIns) {
 		f.Decl.sema.set_current_file(file)
 	}
 
+	// Methods are checked with suppressions of owner structure.
+	// Anonymous functions are checked with suppressions of where they are defined.
+	if !f.anon {
+		var owner_directives []*ast.Directive
+		if f.Decl.Owner != nil {
+			owner_directives = f.Decl.Owner.Directives
+		}
+		prev := f.Decl.sema.enter_allows(owner_directives, f.Decl.Directives)
+		defer func() { f.Decl.sema.allows = prev }()
+	}
+
 	sc := new_scope_checker(f.Decl.sema, f)
 	s.check_fn_ins_sc(f, sc)
 
@@ -1967,6 +2552,65 @@ func (s *_Sema) check_fn_ins(f *FnIns) {
 	}
 }
 
+// Reports whether type is compatible with C ABI.
+func is_c_compatible_type(t *TypeKind) bool {
+	switch {
+	case t == nil:
+		return false
+
+	case t.Prim() != nil:
+		prim := t.Prim()
+		return !prim.Is_str() && !prim.Is_any()
+
+	case t.Ptr() != nil:
+		ptr := t.Ptr()
+		return ptr.Is_unsafe() || is_c_compatible_type(ptr.Elem)
+
+	default:
+		return false
+	}
+}
+
+// Reports whether type is compatible with C function pointers.
+// Cpp-linked types and C function pointers are compatible in addition to C ABI types.
+// Platform dependent integers are not compatible,
+// because they are not same with C types of them.
+func is_c_fnptr_compatible_type(t *TypeKind) bool {
+	switch {
+	case t == nil:
+		return false
+
+	case t.Prim() != nil && is_platform_int(t.Prim().To_str()):
+		return false
+
+	case t.Cpp_linked:
+		return true
+
+	case t.Fnc() != nil:
+		return t.Fnc().Decl.C_fnptr
+
+	case t.Ptr() != nil:
+		ptr := t.Ptr()
+		return ptr.Is_unsafe() || is_c_fnptr_compatible_type(ptr.Elem)
+
+	default:
+		return is_c_compatible_type(t)
+	}
+}
+
+// Checks parameter and result types of exported function instance.
+func (s *_Sema) check_export_fn_ins(f *FnIns) {
+	for _, p := range f.Params {
+		if p.Kind != nil && !is_c_compatible_type(p.Kind) {
+			s.push_err(p.Decl.Token, "export_incompatible_type", p.Kind.To_str())
+		}
+	}
+
+	if f.Result != nil && !f.Result.Is_void() && !is_c_compatible_type(f.Result) {
+		s.push_err(f.Decl.Result.Kind.Decl.Token, "export_incompatible_type", f.Result.To_str())
+	}
+}
+
 func (s *_Sema) check_type_fn(f *Fn) {
 	if f.Cpp_linked {
 		return
@@ -1978,6 +2622,9 @@ func (s *_Sema) check_type_fn(f *Fn) {
 	}
 
 	for _, ins := range f.Instances {
+		if f.Export_ident() != "" {
+			s.check_export_fn_ins(ins)
+		}
 		s.check_fn_ins(ins)
 	}
 }
@@ -2058,6 +2705,57 @@ func (s *_Sema) set_sema_fields() {
 	}
 }
 
+// Reports whether file has generic definitions that never instantiated.
+// Bodies of these definitions are not checked, so references are unknown.
+func has_unchecked_generics(f *SymbolTable) bool {
+	for _, fn := range f.Funcs {
+		if len(fn.Generics) > 0 && len(fn.Instances) == 0 {
+			return true
+		}
+	}
+
+	for _, s := range f.Structs {
+		if len(s.Generics) > 0 && len(s.Instances) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *_Sema) check_unused_import(imp *ImportInfo) {
+	const CLASS = build.WARN_UNUSED_IMPORT
+
+	switch {
+	case imp.Cpp_linked:
+		return
+
+	case !imp.Used:
+		s.push_warn(CLASS, imp.Token, "unused_import", imp.Link_path)
+		return
+	}
+
+	for _, ident := range imp.Selected {
+		if !imp.is_used_selection(ident.Kind) {
+			s.push_warn(CLASS, ident, "unused_import_selection", ident.Kind, imp.Link_path)
+		}
+	}
+}
+
+// Checks unused use declarations and selections of package.
+// Must be called after package checked.
+// Files have uninstantiated generics are skipped.
+func (s *_Sema) check_unused_imports() {
+	for _, f := range s.files {
+		if has_unchecked_generics(f) {
+			continue
+		}
+
+		for _, imp := range f.Imports {
+			s.check_unused_import(imp)
+		}
+	}
+}
+
 func (s *_Sema) check(files []*SymbolTable) {
 	s.files = files
 
@@ -0,0 +1,125 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/parser"
+)
+
+// Importer of tests, imports packages from file system.
+type _TestImporter struct {
+	t        *testing.T
+	packages []*ImportInfo
+}
+
+func (i *_TestImporter) Get_import(path string) *ImportInfo {
+	for _, p := range i.packages {
+		if p.Path == path {
+			return p
+		}
+	}
+	return nil
+}
+
+func (i *_TestImporter) Import_package(path string) ([]*ast.Ast, []build.Log) {
+	dirents, err := os.ReadDir(path)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+
+	var asts []*ast.Ast
+	for _, dirent := range dirents {
+		if dirent.IsDir() {
+			continue
+		}
+		asts = append(asts, parse_test_file(i.t, filepath.Join(path, dirent.Name())))
+	}
+	return asts, nil
+}
+
+func (i *_TestImporter) Imported(imp *ImportInfo) {
+	i.packages = append(i.packages, imp)
+}
+
+// Writes files of package into directory.
+func write_test_package(t *testing.T, dir string, files map[string]string) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Returns abstract syntax tree of file.
+func parse_test_file(t *testing.T, path string) *ast.Ast {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := lex.New_file_set(path)
+	logs := lex.Lex(file, text)
+	if len(logs) > 0 {
+		t.Fatalf("%s: %s", path, logs[0].Text)
+	}
+
+	finfo := parser.Parse_file(file)
+	if len(finfo.Errors) > 0 {
+		t.Fatalf("%s: %s", path, finfo.Errors[0].Text)
+	}
+	return finfo.Ast
+}
+
+// Analyzes main package which is imports package q.
+func analyze_test_package(t *testing.T, q string) []build.Log {
+	dir := t.TempDir()
+	write_test_package(t, dir, map[string]string{
+		"main.jule": "use q\n\nfn main() {\n\tq::f()\n}\n",
+	})
+	write_test_package(t, filepath.Join(dir, "q"), map[string]string{
+		"q.jule": q,
+	})
+
+	files := []*ast.Ast{parse_test_file(t, filepath.Join(dir, "main.jule"))}
+	_, logs := Analyze_package(files, &_TestImporter{t: t})
+	return logs
+}
+
+func Test_imported_unused_var(t *testing.T) {
+	logs := analyze_test_package(t, "pub fn f() {\n\tlet x = 1\n}\n")
+
+	for _, log := range logs {
+		if log.Type == build.ERR && log.Class == build.WARN_UNUSED {
+			return
+		}
+	}
+	t.Errorf("unused variable of imported package is not reported as error")
+}
+
+func Test_imported_warn(t *testing.T) {
+	level := build.WARN_LEVELS[build.WARN_UNUSED]
+	build.WARN_LEVELS[build.WARN_UNUSED] = build.WARN_LEVEL_WARN
+	defer func() { build.WARN_LEVELS[build.WARN_UNUSED] = level }()
+
+	logs := analyze_test_package(t, "pub fn f() {\n\tlet x = 1\n}\n")
+
+	for _, log := range logs {
+		if log.Class == build.WARN_UNUSED {
+			t.Errorf("warning of imported package is reported: %s", log.Text)
+		}
+	}
+}
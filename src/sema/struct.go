@@ -5,6 +5,8 @@
 package sema
 
 import (
+	"strconv"
+
 	"github.com/julelang/jule/ast"
 	"github.com/julelang/jule/build"
 	"github.com/julelang/jule/lex"
@@ -148,6 +150,40 @@ func (s *Struct) Is_derives(ident string) bool {
 	return false
 }
 
+// Returns directive of structure by tag.
+// Returns nil if structure has not directive.
+func (s *Struct) find_directive(tag string) *ast.Directive {
+	for _, d := range s.Directives {
+		if d.Tag == tag {
+			return d
+		}
+	}
+	return nil
+}
+
+// Reports whether structure has C-compatible layout.
+func (s *Struct) Is_repr_c() bool {
+	d := s.find_directive(build.DIRECTIVE_REPR)
+	return d != nil && len(d.Args) == 1 && d.Args[0] == build.REPR_C
+}
+
+// Reports whether structure is packed.
+func (s *Struct) Is_packed() bool { return s.find_directive(build.DIRECTIVE_PACKED) != nil }
+
+// Returns alignment of structure in bytes.
+// Returns zero if alignment is not specified or invalid.
+func (s *Struct) Align() uint64 {
+	d := s.find_directive(build.DIRECTIVE_ALIGN)
+	if d == nil || len(d.Args) != 1 {
+		return 0
+	}
+	align, err := strconv.ParseUint(d.Args[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return align
+}
+
 // Reports whether structure is uses given structure.
 func (s *Struct) Is_uses(st *Struct) bool {
 	for _, u := range s.Uses {
@@ -0,0 +1,323 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/types"
+)
+
+// Maximum count of suggestions for identifier.
+const _MAX_SUGGESTIONS = 3
+
+// Returns edit distance of identifiers.
+// Insertions, deletions, substitutions and transpositions
+// of adjacent characters are single edits.
+func edit_distance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	// Rows of distance matrix, current and previous two.
+	pprev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min_int(prev[j]+1, min_int(curr[j-1]+1, prev[j-1]+cost))
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min_int(curr[j], pprev[j-2]+1)
+			}
+		}
+		pprev, prev, curr = prev, curr, pprev
+	}
+	return prev[len(rb)]
+}
+
+func min_int(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Returns most similar candidates to identifier.
+// Candidates differ only by case are always similar,
+// others must be in edit distance by length of identifier.
+func get_suggestions(ident string, candidates []string) []string {
+	type suggestion struct {
+		ident    string
+		distance int
+	}
+
+	max := len(ident) / 3
+	if max < 1 {
+		max = 1
+	}
+
+	var suggestions []suggestion
+	for _, candidate := range candidates {
+		if candidate == ident || candidate == "" || lex.Is_ignore_ident(candidate) {
+			continue
+		}
+
+		distance := edit_distance(ident, candidate)
+		if strings.EqualFold(ident, candidate) {
+			distance = 0
+		} else if distance > max {
+			continue
+		}
+
+		exist := false
+		for _, s := range suggestions {
+			if s.ident == candidate {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].distance == suggestions[j].distance {
+			return suggestions[i].ident < suggestions[j].ident
+		}
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	if len(suggestions) > _MAX_SUGGESTIONS {
+		suggestions = suggestions[:_MAX_SUGGESTIONS]
+	}
+
+	idents := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		idents[i] = s.ident
+	}
+	return idents
+}
+
+// Returns "did you mean" text of most similar candidates to identifier.
+// Returns empty string if there is no similar candidate.
+func get_suggestion_text(ident string, candidates []string) string {
+	suggestions := get_suggestions(ident, candidates)
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	for i, s := range suggestions {
+		suggestions[i] = strconv.Quote(s)
+	}
+
+	text := suggestions[len(suggestions)-1]
+	if len(suggestions) > 1 {
+		text = strings.Join(suggestions[:len(suggestions)-1], ", ") + " or " + text
+	}
+	return build.Errorf("did_you_mean", text)
+}
+
+// Pushes error with suggestions of most similar candidates to identifier.
+func (s *_Sema) push_suggested_err(token lex.Token, ident string, candidates []string, key string, args ...any) {
+	log := compiler_err(token, key, args...)
+	suggestion := get_suggestion_text(ident, candidates)
+	if suggestion != "" {
+		log.Text += "; " + suggestion
+	}
+	s.errors = append(s.errors, log)
+}
+
+// Returns identifiers of defines of symbol table.
+// Public defines returned only if public is true.
+func get_table_idents(t *SymbolTable, cpp_linked bool, public bool) []string {
+	var idents []string
+	for _, v := range t.Vars {
+		if v.Cpp_linked == cpp_linked && (!public || v.Public) {
+			idents = append(idents, v.Ident)
+		}
+	}
+
+	for _, ta := range t.Type_aliases {
+		if ta.Cpp_linked == cpp_linked && (!public || ta.Public) {
+			idents = append(idents, ta.Ident)
+		}
+	}
+
+	for _, s := range t.Structs {
+		if s.Cpp_linked == cpp_linked && (!public || s.Public) {
+			idents = append(idents, s.Ident)
+		}
+	}
+
+	for _, f := range t.Funcs {
+		if f.Cpp_linked == cpp_linked && (!public || f.Public) {
+			idents = append(idents, f.Ident)
+		}
+	}
+
+	if cpp_linked {
+		return idents
+	}
+
+	for _, trt := range t.Traits {
+		if !public || trt.Public {
+			idents = append(idents, trt.Ident)
+		}
+	}
+
+	for _, e := range t.Enums {
+		if !public || e.Public {
+			idents = append(idents, e.Ident)
+		}
+	}
+	return idents
+}
+
+// Returns identifiers of public defines of imported package.
+func (i *ImportInfo) get_public_idents() []string {
+	if i.Cpp_linked || i.Package == nil {
+		return nil
+	}
+
+	var idents []string
+	for _, f := range i.Package.Files {
+		idents = append(idents, get_table_idents(f, false, true)...)
+	}
+	return idents
+}
+
+// Returns identifiers of defines which are lookupable by import way.
+func (i *ImportInfo) get_idents() []string {
+	var idents []string
+	for _, ident := range i.get_public_idents() {
+		if i.is_lookupable(ident) {
+			idents = append(idents, ident)
+		}
+	}
+	return idents
+}
+
+// Returns link paths of imported packages of current file.
+func (s *_Sema) get_namespaces() []string {
+	var paths []string
+	for _, imp := range s.file.Imports {
+		if !imp.Cpp_linked && imp.is_lookupable(lex.KND_SELF) {
+			paths = append(paths, imp.Link_path)
+		}
+	}
+	return paths
+}
+
+// Returns identifiers of defines of package files, imported packages
+// of current file and built-in defines.
+func (s *_Sema) get_idents(cpp_linked bool) []string {
+	var idents []string
+	for _, f := range s.files {
+		idents = append(idents, get_table_idents(f, cpp_linked, false)...)
+	}
+
+	if cpp_linked {
+		return idents
+	}
+
+	for _, imp := range s.file.Imports {
+		idents = append(idents, imp.get_idents()...)
+	}
+	return append(idents, builtin_idents[:]...)
+}
+
+// Returns identifiers of defines of scope, parent scopes and sema.
+func (sc *_ScopeChecker) get_idents(cpp_linked bool) []string {
+	var idents []string
+	for scope := sc; scope != nil; scope = scope.parent {
+		idents = append(idents, get_table_idents(scope.table, cpp_linked, false)...)
+	}
+	return append(idents, sc.s.get_idents(cpp_linked)...)
+}
+
+// Returns identifiers of defines which are lookupable by lookup.
+func get_lookup_idents(l Lookup, cpp_linked bool) []string {
+	switch l.(type) {
+	case *_ScopeChecker:
+		return l.(*_ScopeChecker).get_idents(cpp_linked)
+
+	case *_Sema:
+		return l.(*_Sema).get_idents(cpp_linked)
+
+	case *ImportInfo:
+		if cpp_linked {
+			return nil
+		}
+		return l.(*ImportInfo).get_idents()
+
+	default:
+		return nil
+	}
+}
+
+// Returns identifiers of accessible fields of structure.
+func (s *_Sema) get_field_idents(strct *StructIns) []string {
+	var idents []string
+	for _, f := range strct.Fields {
+		if s.is_accessible_define(f.Decl.Public, f.Decl.Token) {
+			idents = append(idents, f.Decl.Ident)
+		}
+	}
+	return idents
+}
+
+// Returns identifiers of accessible fields and methods of structure.
+func (s *_Sema) get_struct_idents(strct *StructIns) []string {
+	idents := s.get_field_idents(strct)
+	for _, m := range strct.Methods {
+		idents = append(idents, m.Ident)
+	}
+	return idents
+}
+
+// Returns identifiers of methods of trait.
+func get_trait_idents(t *Trait) []string {
+	idents := make([]string, len(t.Methods))
+	for i, m := range t.Methods {
+		idents[i] = m.Ident
+	}
+	return idents
+}
+
+// Returns identifiers of items of enum.
+func get_enum_idents(e *Enum) []string {
+	idents := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		idents[i] = item.Ident
+	}
+	return idents
+}
+
+// Returns identifiers of statics of primitive type.
+func get_prim_static_idents(kind string) []string {
+	switch {
+	case !types.Is_num(kind):
+		return nil
+
+	case types.Is_unsig_int(kind):
+		return []string{"MAX"}
+
+	default:
+		return []string{"MAX", "MIN"}
+	}
+}
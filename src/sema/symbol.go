@@ -20,6 +20,12 @@ type Pass struct {
 	Text  string
 }
 
+// Library of jule:link directive.
+type Link struct {
+	Token lex.Token
+	Lib   string
+}
+
 func build_doc(cg *ast.CommentGroup) string {
 	if cg == nil {
 		return ""
@@ -62,6 +68,7 @@ func build_type_alias(decl *ast.TypeAliasDecl) *TypeAlias {
 		Ident:      decl.Ident,
 		Kind:       build_type(decl.Kind),
 		Doc:        build_doc(decl.Doc_comments),
+		Generics:   decl.Generics,
 	}
 }
 
@@ -131,6 +138,7 @@ func build_fn(decl *ast.FnDecl) *Fn {
 		Unsafety:   decl.Unsafety,
 		Public:     decl.Public,
 		Cpp_linked: decl.Cpp_linked,
+		C_fnptr:    decl.C_fnptr,
 		Ident:      decl.Ident,
 		Directives: decl.Directives,
 		Doc:        build_doc(decl.Doc_comments),
@@ -151,11 +159,12 @@ func build_methods(decls []*ast.FnDecl) []*Fn {
 
 func build_trait(decl *ast.TraitDecl) *Trait {
 	return &Trait{
-		Token:   decl.Token,
-		Ident:   decl.Ident,
-		Public:  decl.Public,
-		Doc:     build_doc(decl.Doc_comments),
-		Methods: build_methods(decl.Methods),
+		Token:      decl.Token,
+		Ident:      decl.Ident,
+		Public:     decl.Public,
+		Directives: decl.Directives,
+		Doc:        build_doc(decl.Doc_comments),
+		Methods:    build_methods(decl.Methods),
 	}
 }
 
@@ -177,12 +186,13 @@ func build_enum_items(decls []*ast.EnumItemDecl) []*EnumItem {
 
 func build_enum(decl *ast.EnumDecl) *Enum {
 	return &Enum{
-		Token:  decl.Token,
-		Public: decl.Public,
-		Ident:  decl.Ident,
-		Kind:   build_type(decl.Kind),
-		Items:  build_enum_items(decl.Items),
-		Doc:    build_doc(decl.Doc_comments),
+		Token:      decl.Token,
+		Public:     decl.Public,
+		Ident:      decl.Ident,
+		Kind:       build_type(decl.Kind),
+		Items:      build_enum_items(decl.Items),
+		Directives: decl.Directives,
+		Doc:        build_doc(decl.Doc_comments),
 	}
 }
 
@@ -190,11 +200,14 @@ func build_var(decl *ast.VarDecl) *Var {
 	return &Var{
 		Scope:      decl.Scope,
 		Token:      decl.Token,
+		Begin:      decl.Begin,
+		End:        decl.End,
 		Ident:      decl.Ident,
 		Cpp_linked: decl.Cpp_linked,
 		Constant:   decl.Constant,
 		Mutable:    decl.Mutable,
 		Public:     decl.Public,
+		Directives: decl.Directives,
 		Doc:        build_doc(decl.Doc_comments),
 		Kind:       build_type(decl.Kind),
 		Value:      build_expr(decl.Expr),
@@ -233,8 +246,10 @@ func (s *_SymbolBuilder) push_err(token lex.Token, key string, args ...any) {
 		Type:   build.ERR,
 		Row:    token.Row,
 		Column: token.Column,
+		Length: len(token.Kind),
 		Path:   token.File.Path(),
 		Text:   build.Errorf(key, args...),
+		Code:   build.Code(key),
 	})
 }
 
@@ -259,9 +274,33 @@ func (s *_SymbolBuilder) check_cpp_use_decl_path(decl *ast.UseDecl) (ok bool) {
 	return true
 }
 
+func (s *_SymbolBuilder) check_cpp_use_decl_flags(decl *ast.UseDecl) (ok bool) {
+	if decl.Cpp_flags == "" {
+		return true
+	}
+
+	if build.Is_std_header_path(decl.Link_path) || !build.Is_valid_cpp_ext(filepath.Ext(decl.Link_path)) {
+		s.push_err(decl.Token, "cpp_flags_for_non_source")
+		return false
+	}
+
+	for _, flag := range strings.Fields(decl.Cpp_flags) {
+		if flag[0] != '-' {
+			s.push_err(decl.Token, "cpp_flag_not_starts_with_dash", flag)
+			return false
+		}
+	}
+
+	return true
+}
+
 func (s *_SymbolBuilder) build_cpp_header_import(decl *ast.UseDecl) *ImportInfo {
 	path := decl.Link_path
 
+	if !s.check_cpp_use_decl_flags(decl) {
+		return nil
+	}
+
 	if !build.Is_std_header_path(decl.Link_path) {
 		ok := s.check_cpp_use_decl_path(decl)
 		if !ok {
@@ -282,6 +321,7 @@ func (s *_SymbolBuilder) build_cpp_header_import(decl *ast.UseDecl) *ImportInfo
 		Link_path:  decl.Link_path,
 		Ident:      "", // Cpp headers haven't identifiers.
 		Cpp_linked: true,
+		Cpp_flags:  decl.Cpp_flags,
 		Std:        false,
 		Package:    nil, // Cpp headers haven't symbol table.
 	}
@@ -594,11 +634,27 @@ func (s *_SymbolBuilder) push_directive_pass(d *ast.Directive) {
 	s.table.Passes = append(s.table.Passes, pass)
 }
 
+func (s *_SymbolBuilder) push_directive_link(d *ast.Directive) {
+	link := Link{
+		Token: d.Token,
+	}
+	for _, arg := range d.Args {
+		if arg != "" {
+			link.Lib += arg + " "
+		}
+	}
+	link.Lib = strings.TrimSpace(link.Lib)
+	s.table.Links = append(s.table.Links, link)
+}
+
 func (s *_SymbolBuilder) append_top_directives() {
 	for _, d := range s.ast.Top_directives {
 		switch d.Tag {
 		case build.DIRECTIVE_PASS:
 			s.push_directive_pass(d)
+
+		case build.DIRECTIVE_LINK:
+			s.push_directive_link(d)
 		}
 	}
 }
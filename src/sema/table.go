@@ -13,6 +13,7 @@ import "github.com/julelang/jule/lex"
 type SymbolTable struct {
 	File         *lex.File     // Owner fileset of this symbol table.
 	Passes       []Pass        // All passed flags with jule:pass directive.
+	Links        []Link        // All linked libraries with jule:link directive.
 	Imports      []*ImportInfo // Imported packages.
 	Vars         []*Var        // Variables.
 	Type_aliases []*TypeAlias  // Type aliases.
@@ -190,46 +191,47 @@ func (st *SymbolTable) def_by_ident(ident string, cpp_linked bool) any {
 // Reports this identifier duplicated in symbol table.
 // The "self" parameter represents address of exception identifier.
 // If founded identifier address equals to self, will be skipped.
-func (st *SymbolTable) is_duplicated_ident(itself uintptr, ident string, cpp_linked bool) bool {
+// Returns token of duplicated define as first result.
+func (st *SymbolTable) find_duplicated_ident(itself uintptr, ident string, cpp_linked bool) (lex.Token, bool) {
 	for _, v := range st.Vars {
 		if _uintptr(v) != itself && v.Ident == ident && v.Cpp_linked == cpp_linked {
-			return true
+			return v.Token, true
 		}
 	}
 
 	for _, ta := range st.Type_aliases {
 		if _uintptr(ta) != itself && ta.Ident == ident && ta.Cpp_linked == cpp_linked {
-			return true
+			return ta.Token, true
 		}
 	}
 
 	for _, s := range st.Structs {
 		if _uintptr(s) != itself && s.Ident == ident && s.Cpp_linked == cpp_linked {
-			return true
+			return s.Token, true
 		}
 	}
 
 	for _, f := range st.Funcs {
 		if _uintptr(f) != itself && f.Ident == ident && f.Cpp_linked == cpp_linked {
-			return true
+			return f.Token, true
 		}
 	}
 
 	if cpp_linked {
-		return false
+		return lex.Token{}, false
 	}
 
 	for _, t := range st.Traits {
 		if _uintptr(t) != itself && t.Ident == ident {
-			return true
+			return t.Token, true
 		}
 	}
 
 	for _, e := range st.Enums {
 		if _uintptr(e) != itself && e.Ident == ident {
-			return true
+			return e.Token, true
 		}
 	}
 
-	return false
+	return lex.Token{}, false
 }
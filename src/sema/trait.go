@@ -7,16 +7,18 @@ package sema
 import (
 	"strconv"
 
+	"github.com/julelang/jule/ast"
 	"github.com/julelang/jule/lex"
 )
 
 // Trait.
 type Trait struct {
-	Token   lex.Token
-	Ident   string
-	Public  bool
-	Doc     string
-	Methods []*Fn
+	Token      lex.Token
+	Ident      string
+	Public     bool
+	Directives []*ast.Directive
+	Doc        string
+	Methods    []*Fn
 }
 
 // Implement: Kind
@@ -27,7 +27,8 @@ type TypeAlias struct {
 	Ident      string
 	Kind       *TypeSymbol
 	Doc        string
-	Refers     []any // Referred identifiers.
+	Refers     []any               // Referred identifiers.
+	Generics   []*ast.GenericDecl // Generics of cpp-linked type alias.
 }
 
 type _Kind interface {
@@ -36,9 +37,10 @@ type _Kind interface {
 
 // Type's kind's type.
 type TypeKind struct {
-	Cpp_linked bool
-	Cpp_ident  string
-	kind       _Kind
+	Cpp_linked   bool
+	Cpp_ident    string
+	Cpp_generics []*TypeKind // Generics of cpp-linked generic type.
+	kind         _Kind
 }
 
 // Returns clone.
@@ -50,6 +52,7 @@ func (tk *TypeKind) clone() *TypeKind {
 	kind := new(TypeKind)
 	kind.Cpp_ident = tk.Cpp_ident
 	kind.Cpp_linked = tk.Cpp_linked
+	kind.Cpp_generics = tk.Cpp_generics
 	kind.kind = tk.kind
 	return kind
 }
@@ -61,7 +64,18 @@ func (tk TypeKind) To_str() string {
 	}
 
 	if tk.Cpp_linked {
-		return "cpp." + tk.Cpp_ident
+		kind := "cpp." + tk.Cpp_ident
+		if len(tk.Cpp_generics) > 0 {
+			kind += "["
+			for i, g := range tk.Cpp_generics {
+				if i > 0 {
+					kind += ","
+				}
+				kind += g.To_str()
+			}
+			kind += "]"
+		}
+		return kind
 	}
 
 	return tk.kind.To_str()
@@ -552,28 +566,46 @@ func (tc *_TypeChecker) check_illegal_cycles(ident *ast.IdentTypeDecl, decl any)
 	const PADDING = 4
 
 	message := ""
+	var cycle []any // Defines of cycle in order of message.
 
-	push := func(def1 any, def2 any) {
-		get_ident := func(def any) string {
-			switch def.(type) {
-			case *TypeAlias:
-				return def.(*TypeAlias).Ident
+	get_ident := func(def any) string {
+		switch def.(type) {
+		case *TypeAlias:
+			return def.(*TypeAlias).Ident
 
-			case *Struct:
-				return def.(*Struct).Ident
+		case *Struct:
+			return def.(*Struct).Ident
 
-			case *Enum:
-				return def.(*Enum).Ident
+		case *Enum:
+			return def.(*Enum).Ident
 
-			default:
-				return ""
-			}
+		default:
+			return ""
 		}
+	}
 
+	get_token := func(def any) lex.Token {
+		switch def.(type) {
+		case *TypeAlias:
+			return def.(*TypeAlias).Token
+
+		case *Struct:
+			return def.(*Struct).Token
+
+		case *Enum:
+			return def.(*Enum).Token
+
+		default:
+			return lex.Token{}
+		}
+	}
+
+	push := func(def1 any, def2 any) {
 		def1_ident := get_ident(def1)
 		def2_ident := get_ident(def2)
 		refers_to := build.Errorf("refers_to", def1_ident, def2_ident)
 		message = strings.Repeat(" ", PADDING) + refers_to + "\n" + message
+		cycle = append([]any{def1}, cycle...)
 	}
 
 	// Check cross illegal cycle.
@@ -619,6 +651,11 @@ func (tc *_TypeChecker) check_illegal_cycles(ident *ast.IdentTypeDecl, decl any)
 		push(tc.referencer.owner, decl)
 		err_msg = err_msg + message
 		tc.push_err(ident.Token, "illegal_cross_cycle", err_msg)
+		// Owner is prepended last, but its line is the last one of message.
+		cycle = append(cycle[1:], cycle[0])
+		for _, def := range cycle {
+			tc.s.push_err_note(get_token(def), "declared_here", get_ident(def))
+		}
 		return false
 	}
 
@@ -645,6 +682,10 @@ func (tc *_TypeChecker) from_type_alias(decl *ast.IdentTypeDecl, ta *TypeAlias)
 
 	ta.Used = true
 
+	if len(ta.Generics) > 0 {
+		return tc.from_generic_type_alias(decl, ta)
+	}
+
 	if len(decl.Generics) > 0 {
 		tc.push_err(decl.Token, "type_not_supports_generics", decl.Ident)
 		return nil
@@ -671,6 +712,59 @@ func (tc *_TypeChecker) from_type_alias(decl *ast.IdentTypeDecl, ta *TypeAlias)
 	return kind
 }
 
+// Builds kind of generic cpp-linked type alias for generics of type declaration.
+// Kind of type alias is built for each use with generics.
+func (tc *_TypeChecker) from_generic_type_alias(decl *ast.IdentTypeDecl, ta *TypeAlias) _Kind {
+	if len(tc.ignore_generics) > 0 {
+		// Ignore prototypes.
+		return nil
+	}
+
+	ok := tc.s.check_generic_quantity(len(ta.Generics), len(decl.Generics), decl.Token)
+	if !ok {
+		return nil
+	}
+
+	generics := make([]*TypeKind, len(decl.Generics))
+	use_generics := make([]*TypeAlias, len(decl.Generics))
+	for i, g := range decl.Generics {
+		kind := tc.build(g.Kind)
+		if kind == nil {
+			return nil
+		}
+		generics[i] = kind
+		use_generics[i] = &TypeAlias{
+			Ident: ta.Generics[i].Ident,
+			Kind: &TypeSymbol{
+				Kind: kind,
+			},
+		}
+	}
+
+	f := tc.s.file
+	defer tc.s.set_current_file(f)
+	f = find_file(tc.s.files, ta.Token.File)
+	if f != nil {
+		tc.s.set_current_file(f)
+	}
+
+	atc := _TypeChecker{
+		s:            tc.s,
+		lookup:       tc.lookup,
+		use_generics: use_generics,
+	}
+	kind := atc.check_decl(ta.Kind.Decl)
+	if kind == nil {
+		return nil
+	}
+
+	kind = kind.clone()
+	kind.Cpp_linked = true
+	kind.Cpp_ident = ta.Ident
+	kind.Cpp_generics = generics
+	return kind
+}
+
 func (tc *_TypeChecker) from_enum(decl *ast.IdentTypeDecl, e *Enum) *Enum {
 	if !tc.s.is_accessible_define(e.Public, e.Token) {
 		tc.push_err(decl.Token, "ident_not_exist", decl.Ident)
@@ -796,6 +890,12 @@ func (tc *_TypeChecker) from_struct(decl *ast.IdentTypeDecl, s *Struct) *StructI
 		return nil
 	}
 
+	// Check before instancing, instances are compared by generics.
+	ok = tc.s.check_generic_quantity(len(s.Generics), len(decl.Generics), decl.Token)
+	if !ok {
+		return nil
+	}
+
 	tc.append_used_struct_reference(s)
 
 	ins := s.instance()
@@ -854,6 +954,7 @@ func (tc *_TypeChecker) get_def(decl *ast.IdentTypeDecl) _Kind {
 	if !decl.Cpp_linked {
 		e := tc.lookup.Find_enum(decl.Ident)
 		if e != nil {
+			tc.s.check_deprecated(e.Directives, e.Ident, e.Token, decl.Token)
 			return tc.from_enum(decl, e)
 		}
 
@@ -871,13 +972,19 @@ func (tc *_TypeChecker) get_def(decl *ast.IdentTypeDecl) _Kind {
 				tc.push_err(decl.Token, "type_not_supports_generics", decl.Ident)
 				return nil
 			}
+			tc.s.check_deprecated(t.Directives, t.Ident, t.Token, decl.Token)
 			return t
 		}
 	}
 
 	s := tc.lookup.Find_struct(decl.Ident, decl.Cpp_linked)
 	if s != nil {
-		return tc.from_struct(decl, s)
+		tc.s.check_deprecated(s.Directives, s.Ident, s.Token, decl.Token)
+		ins := tc.from_struct(decl, s)
+		if ins == nil {
+			return nil
+		}
+		return ins
 	}
 
 	ta := tc.lookup.Find_type_alias(decl.Ident, decl.Cpp_linked)
@@ -888,7 +995,8 @@ func (tc *_TypeChecker) get_def(decl *ast.IdentTypeDecl) _Kind {
 		return tc.from_type_alias(decl, ta)
 	}
 
-	tc.push_err(decl.Token, "ident_not_exist", decl.Ident)
+	idents := get_lookup_idents(tc.lookup, decl.Cpp_linked)
+	tc.s.push_suggested_err(decl.Token, decl.Ident, idents, "ident_not_exist", decl.Ident)
 	return nil
 }
 
@@ -1112,9 +1220,36 @@ func (tc *_TypeChecker) build_fn(decl *ast.FnDecl) *FnIns {
 		return nil
 	}
 
+	if f.C_fnptr && !tc.check_c_fnptr(ins) {
+		return nil
+	}
+
 	return ins
 }
 
+// Checks parameters and result of C function pointer type.
+func (tc *_TypeChecker) check_c_fnptr(f *FnIns) (ok bool) {
+	ok = true
+	for _, p := range f.Params {
+		switch {
+		case p.Decl.Variadic:
+			tc.push_err(p.Decl.Token, "c_fnptr_variadic")
+			ok = false
+
+		case !is_c_fnptr_compatible_type(p.Kind):
+			tc.push_err(p.Decl.Token, "c_fnptr_incompatible_type", p.Kind.To_str())
+			ok = false
+		}
+	}
+
+	if f.Result != nil && !is_c_fnptr_compatible_type(f.Result) {
+		tc.push_err(f.Decl.Result.Kind.Decl.Token, "c_fnptr_incompatible_type", f.Result.To_str())
+		ok = false
+	}
+
+	return ok
+}
+
 func (tc *_TypeChecker) build_by_std_namespace(decl *ast.NamespaceTypeDecl) _Kind {
 	path := build_link_path_by_tokens(decl.Idents)
 	imp := tc.lookup.Select_package(func(imp *ImportInfo) bool {
@@ -1122,9 +1257,10 @@ func (tc *_TypeChecker) build_by_std_namespace(decl *ast.NamespaceTypeDecl) _Kin
 	})
 
 	if imp == nil || !imp.is_lookupable(lex.KND_SELF) {
-		tc.push_err(decl.Idents[0], "namespace_not_exist", path)
+		tc.s.push_suggested_err(decl.Idents[0], path, tc.s.get_namespaces(), "namespace_not_exist", path)
 		return nil
 	}
+	imp.use(lex.KND_SELF)
 
 	lookup := tc.lookup
 	tc.lookup = imp
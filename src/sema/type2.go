@@ -3,6 +3,7 @@ package sema
 import (
 	"math"
 	"strconv"
+	"strings"
 
 	"github.com/julelang/jule/ast"
 	"github.com/julelang/jule/lex"
@@ -305,6 +306,9 @@ func (tcc *_TypeCompatibilityChecker) check_fn() (ok bool) {
 	}
 
 	dest := tcc.dest.Fnc()
+	if src.Decl.C_fnptr != dest.Decl.C_fnptr {
+		return false
+	}
 	if (src.Result != nil) != (dest.Result != nil) {
 		return false
 	}
@@ -484,9 +488,52 @@ func (atc *_AssignTypeChecker) check() bool {
 	case atc.check_const():
 		return true
 
+	case atc.dest.Fnc() != nil && atc.dest.Fnc().Decl.C_fnptr:
+		return atc.check_c_fnptr()
+
 	default:
+		ok := atc.s.check_type_compatibility(atc.dest, atc.d.Kind, atc.error_token, atc.deref)
+		if ok {
+			atc.check_lossy_conv()
+		}
+		return ok
+	}
+}
+
+// Checks assignment to C function pointer.
+// Global functions are assignable if signatures are same.
+func (atc *_AssignTypeChecker) check_c_fnptr() bool {
+	src := atc.d.Kind.Fnc()
+	if src == nil || src.Decl.C_fnptr {
 		return atc.s.check_type_compatibility(atc.dest, atc.d.Kind, atc.error_token, atc.deref)
 	}
+
+	// Anonymous functions may capture and variables are not C function pointers.
+	var f *FnIns
+	switch atc.d.Model.(type) {
+	case *FnIns:
+		f = atc.d.Model.(*FnIns)
+
+	case *CFnptrExprModel:
+		// Already checked.
+		f = atc.d.Model.(*CFnptrExprModel).Func
+	}
+	if f == nil || f.Is_anon() {
+		atc.push_err("c_fnptr_not_global_fn")
+		return false
+	}
+
+	dest := atc.dest.To_str()
+	if strings.TrimPrefix(dest, lex.KND_CPP+" ") != src.To_str() {
+		atc.push_err("incompatible_types", dest, src.To_str())
+		return false
+	}
+
+	atc.d.Model = &CFnptrExprModel{
+		Kind: atc.dest.Fnc(),
+		Func: f,
+	}
+	return true
 }
 
 type _DynamicTypeAnnotation struct {
@@ -746,8 +793,9 @@ func (fcac *_FnCallArgChecker) push(p *ParamIns, arg *ast.Expr) (ok bool) {
 	if d == nil {
 		return false
 	}
+	ok = fcac.check_arg(p, d, arg.Token)
 	fcac.arg_models = append(fcac.arg_models, d.Model)
-	return fcac.check_arg(p, d, arg.Token)
+	return ok
 }
 
 func (fcac *_FnCallArgChecker) push_variadic(p *ParamIns, i int) (ok bool) {
@@ -862,19 +910,20 @@ func (slc *_StructLitChecker) push_err(token lex.Token, key string, args ...any)
 }
 
 func (slc *_StructLitChecker) push_match(f *FieldIns, d *Data, error_token lex.Token) {
+	slc.e.s.check_validity_for_init_expr(f.Decl.Mutable, f.Kind, d, error_token)
+	slc.e.s.check_assign_type(f.Kind, d, error_token, false)
 	slc.args = append(slc.args, &StructArgExprModel{
 		Field: f,
 		Expr:  d.Model,
 	})
-	slc.e.s.check_validity_for_init_expr(f.Decl.Mutable, f.Kind, d, error_token)
-	slc.e.s.check_assign_type(f.Kind, d, error_token, false)
 }
 
 func (slc *_StructLitChecker) check_pair(pair *ast.FieldExprPair, exprs []ast.ExprData) {
 	// Check existing.
 	f := slc.s.Find_field(pair.Field.Kind)
 	if f == nil {
-		slc.push_err(pair.Field, "ident_not_exist", pair.Field.Kind)
+		idents := slc.e.s.get_field_idents(slc.s)
+		slc.e.s.push_suggested_err(pair.Field, pair.Field.Kind, idents, "ident_not_exist", pair.Field.Kind)
 		return
 	}
 
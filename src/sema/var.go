@@ -6,6 +6,7 @@ package sema
 
 import (
 	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
 	"github.com/julelang/jule/lex"
 )
 
@@ -13,12 +14,15 @@ import (
 type Var struct {
 	Scope      *ast.ScopeTree
 	Token      lex.Token
+	Begin      lex.Token // First token of declaration, zero if not declared by declaration statement.
+	End        lex.Token // Last token of declaration, zero if not declared by declaration statement.
 	Ident      string
 	Cpp_linked bool
 	Constant   bool
 	Mutable    bool
 	Public     bool
 	Used       bool
+	Directives []*ast.Directive
 	Doc        string
 	Kind       *TypeSymbol
 	Value      *Value
@@ -31,5 +35,15 @@ type Var struct {
 // Reports whether variable is initialized explicitly.
 func (v *Var) Is_initialized() bool { return v.Value != nil }
 
+// Reports whether variable is thread-local.
+func (v *Var) Is_thread_local() bool {
+	for _, d := range v.Directives {
+		if d.Tag == build.DIRECTIVE_THREAD_LOCAL {
+			return true
+		}
+	}
+	return false
+}
+
 // Reports whether variable is auto-typed.
 func (v *Var) Is_auto_typed() bool { return v.Kind == nil || v.Kind.Decl == nil }
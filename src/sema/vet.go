@@ -0,0 +1,272 @@
+// Copyright 2023 The Jule Programming Language.
+// Use of this source code is governed by a BSD 3-Clause
+// license that can be found in the LICENSE file.
+
+package sema
+
+import (
+	"strings"
+
+	"github.com/julelang/jule/ast"
+	"github.com/julelang/jule/build"
+	"github.com/julelang/jule/lex"
+	"github.com/julelang/jule/types"
+)
+
+// Returns path of expression such as "a.b[i]".
+// Reports whether expression is side-effect free selection.
+// Function calls and other expressions are not supported.
+func get_expr_path(e ast.ExprData) (string, bool) {
+	switch e.(type) {
+	case *ast.Expr:
+		return get_expr_path(e.(*ast.Expr).Kind)
+
+	case *ast.IdentExpr:
+		ident := e.(*ast.IdentExpr)
+		if ident.Cpp_linked {
+			return lex.KND_CPP + lex.KND_DOT + ident.Ident, true
+		}
+		return ident.Ident, true
+
+	case *ast.LitExpr:
+		return e.(*ast.LitExpr).Value, true
+
+	case *ast.NsSelectionExpr:
+		ns := e.(*ast.NsSelectionExpr)
+		path := ""
+		for _, t := range ns.Ns {
+			path += t.Kind + lex.KND_DBLCOLON
+		}
+		return path + ns.Ident.Kind, true
+
+	case *ast.SubIdentExpr:
+		si := e.(*ast.SubIdentExpr)
+		path := lex.KND_SELF
+		if si.Expr != nil {
+			var ok bool
+			path, ok = get_expr_path(si.Expr)
+			if !ok {
+				return "", false
+			}
+		}
+		return path + lex.KND_DOT + si.Ident.Kind, true
+
+	case *ast.IndexingExpr:
+		i := e.(*ast.IndexingExpr)
+		path, ok := get_expr_path(i.Expr)
+		if !ok {
+			return "", false
+		}
+		index, ok := get_expr_path(i.Index)
+		if !ok {
+			return "", false
+		}
+		return path + lex.KND_LBRACKET + index + lex.KND_RBRACKET, true
+
+	case *ast.UnaryExpr:
+		u := e.(*ast.UnaryExpr)
+		if u.Op.Kind != lex.KND_STAR {
+			return "", false
+		}
+		path, ok := get_expr_path(u.Expr)
+		if !ok {
+			return "", false
+		}
+		return lex.KND_STAR + path, true
+
+	default:
+		return "", false
+	}
+}
+
+// Reports whether expressions are same side-effect free selections.
+func is_same_expr(l ast.ExprData, r ast.ExprData) bool {
+	lp, ok := get_expr_path(l)
+	if !ok {
+		return false
+	}
+	rp, ok := get_expr_path(r)
+	return ok && lp == rp
+}
+
+// Reports whether kind is builtin Error trait or tuple that has it.
+func has_error_kind(t *TypeKind) bool {
+	switch {
+	case t == nil:
+		return false
+
+	case t.Trt() == builtin_trait_error:
+		return true
+
+	case t.Tup() != nil:
+		for _, elem := range t.Tup().Types {
+			if elem.Trt() == builtin_trait_error {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Reports whether kind is platform dependent integer kind.
+func is_platform_int(k string) bool {
+	return k == types.TypeKind_INT || k == types.TypeKind_UINT || k == types.TypeKind_UINTPTR
+}
+
+// Reports whether implicit conversion of integer kind may lose precision.
+// Floats can not represent all integers which have bits more than mantissa.
+// Platform dependent integers may be 32-bit, so 64-bit integers may not fit.
+func is_lossy_conv(dest *TypeKind, src *TypeKind) bool {
+	if dest.Prim() == nil || src.Prim() == nil {
+		return false
+	}
+
+	d := types.Real_kind_of(dest.Prim().kind)
+	s := types.Real_kind_of(src.Prim().kind)
+	switch {
+	case !types.Is_int(s):
+		return false
+
+	case types.Is_float(d):
+		return types.Bitsize_of(s) >= types.Bitsize_of(d)
+
+	case is_platform_int(dest.Prim().kind):
+		return !is_platform_int(src.Prim().kind) && types.Bitsize_of(s) > 32
+
+	default:
+		return false
+	}
+}
+
+// Reports whether kind has float values, such as float fields of structures.
+// Any values are also may be float.
+// Float values are not equal to themselves if they are NaN.
+func has_float_kind(t *TypeKind) bool {
+	switch {
+	case t.Prim() != nil:
+		prim := t.Prim()
+		return prim.Is_any() || types.Is_float(types.Real_kind_of(prim.kind))
+
+	case t.Arr() != nil:
+		return has_float_kind(t.Arr().Elem)
+
+	case t.Strct() != nil:
+		for _, f := range t.Strct().Fields {
+			if has_float_kind(f.Kind) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *_Sema) check_self_assign(l *ast.Expr, r *ast.Expr) {
+	if is_same_expr(l, r) {
+		path, _ := get_expr_path(l)
+		s.push_warn(build.VET_SELF_ASSIGN, l.Token, "self_assign", path)
+	}
+}
+
+// Checks comparison of expression with itself.
+// Operands which may be NaN are not checked, comparison with itself tests NaN.
+func (s *_Sema) check_self_compare(op *ast.BinopExpr, kind *TypeKind) {
+	if has_float_kind(kind) {
+		return
+	}
+
+	result := ""
+	switch op.Op.Kind {
+	case lex.KND_EQS, lex.KND_LESS_EQ, lex.KND_GREAT_EQ:
+		result = lex.KND_TRUE
+
+	case lex.KND_NOT_EQ, lex.KND_LT, lex.KND_GT:
+		result = lex.KND_FALSE
+
+	default:
+		return
+	}
+
+	if is_same_expr(op.Left, op.Right) {
+		s.push_warn(build.VET_SELF_COMPARE, op.Op, "self_compare", result)
+	}
+}
+
+func (s *_Sema) check_const_cond(d *Data, token lex.Token) {
+	if !d.Is_const() || !d.Constant.Is_bool() {
+		return
+	}
+
+	result := lex.KND_FALSE
+	if d.Constant.Read_bool() {
+		result = lex.KND_TRUE
+	}
+	s.push_warn(build.VET_CONST_COND, token, "const_cond", result)
+}
+
+// Checks ignored result of function call statement.
+func (s *_Sema) check_ignored_error(expr *ast.Expr, d *Data) {
+	if !has_error_kind(d.Kind) {
+		return
+	}
+
+	fc := expr.Kind.(*ast.FnCallExpr)
+	ident, ok := get_expr_path(fc.Expr)
+	if !ok {
+		ident = strings.TrimSpace(fc.Token.Kind)
+	}
+	s.push_warn(build.VET_IGNORED_ERROR, expr.Token, "ignored_error", ident)
+}
+
+func (atc *_AssignTypeChecker) check_lossy_conv() {
+	if is_lossy_conv(atc.dest, atc.d.Kind) {
+		atc.s.push_warn(build.VET_LOSSY_CONV, atc.error_token, "lossy_conv", atc.d.Kind.To_str(), atc.dest.To_str())
+	}
+}
+
+// Checks variable shadows variable of parent scopes.
+func (sc *_ScopeChecker) check_shadowing(v *Var) {
+	if lex.Is_ignore_ident(v.Ident) || lex.Is_anon_ident(v.Ident) {
+		return
+	}
+
+	for parent := sc.parent; parent != nil; parent = parent.parent {
+		pv := parent.table.Find_var(v.Ident, false)
+		if pv == nil {
+			continue
+		}
+
+		const CLASS = build.VET_SHADOW
+		if sc.s.push_warn(CLASS, v.Token, "shadows_var", v.Ident) && pv.Token.File != nil {
+			sc.s.push_warn_note(CLASS, pv.Token, "declared_here", pv.Ident)
+		}
+		return
+	}
+}
+
+// Reports whether scope is inside of iteration of current function.
+func (sc *_ScopeChecker) is_in_iter() bool {
+	for scope := sc; scope != nil; scope = scope.parent {
+		if scope.it != 0 {
+			return true
+		}
+		if scope.owner != nil {
+			break
+		}
+	}
+	return false
+}
+
+// Checks deferred scope inside of iteration.
+func (sc *_ScopeChecker) check_defer_in_loop(tree *ast.ScopeTree) {
+	if !tree.Deferred || !sc.is_in_iter() {
+		return
+	}
+
+	for _, node := range tree.Stmts {
+		token := get_node_token(node)
+		if token.File != nil {
+			sc.s.push_warn(build.VET_DEFER_LOOP, token, "defer_in_loop")
+			return
+		}
+	}
+}